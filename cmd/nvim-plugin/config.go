@@ -0,0 +1,160 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/vintharas/nvim-plugin/pkg/config"
+	"github.com/vintharas/nvim-plugin/pkg/ui"
+)
+
+// runConfig runs a subcommand of the config command
+func runConfig(args []string) error {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, usage)
+		return fmt.Errorf("missing config command: get, set or edit")
+	}
+
+	switch args[0] {
+	case "get":
+		return configGet(os.Stdout, args[1:])
+	case "set":
+		return configSet(args[1:])
+	case "edit":
+		return configEdit()
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		return fmt.Errorf("unknown config command %q", args[0])
+	}
+}
+
+// configGet prints the value of a setting in effect, environment variables
+// included, or every setting without a key
+func configGet(w io.Writer, args []string) error {
+	if len(args) > 1 {
+		return fmt.Errorf("usage: nvim-plugin config get [key]")
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 1 {
+		value, err := cfg.Get(args[0])
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, value)
+		return nil
+	}
+
+	for _, key := range config.Keys {
+		value, _ := cfg.Get(key.Name)
+		fmt.Fprintf(w, "%s = %s\n", key.Name, strconv.Quote(value))
+	}
+	// Profiles only list the settings they change
+	for _, name := range cfg.ProfileNames() {
+		for _, key := range config.Keys {
+			name := "profiles." + name + "." + key.Name
+			if value, _ := cfg.Get(name); value != "" {
+				fmt.Fprintf(w, "%s = %s\n", name, strconv.Quote(value))
+			}
+		}
+	}
+	return nil
+}
+
+// configSet changes a setting in the config file, an empty value unsetting it
+func configSet(args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("usage: nvim-plugin config set key value")
+	}
+	key, value := args[0], args[1]
+	if err := validateSetting(key, value); err != nil {
+		return err
+	}
+
+	// Environment variables only override the settings, they are not saved
+	path, err := config.Path()
+	if err != nil {
+		return err
+	}
+	return config.SaveSetting(path, key, value)
+}
+
+// validateSetting catches typos in the settings naming a flavor, a test
+// framework, a GitHub user or a host before they are saved, in a profile or not
+func validateSetting(key, value string) error {
+	if value == "" {
+		return nil
+	}
+	if profileKey, found := strings.CutPrefix(key, "profiles."); found {
+		_, key, _ = strings.Cut(profileKey, ".")
+	}
+	switch key {
+	case "defaults.flavor":
+		_, err := ui.LookupFlavor(value)
+		return err
+	case "defaults.test_framework":
+		return ui.ValidateTestFramework(value)
+	case "github.user":
+		return ui.ValidateOwner(value)
+	case "defaults.host":
+		return ui.ValidateHost(value)
+	}
+	return nil
+}
+
+// configEdit opens the config file in the editor of the user, creating it
+// with every key commented out when it does not exist yet
+func configEdit() error {
+	path, err := config.Path()
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return fmt.Errorf("failed to create config directory: %w", err)
+		}
+		if err := os.WriteFile(path, []byte(configTemplate()), 0o644); err != nil {
+			return fmt.Errorf("failed to write config file: %w", err)
+		}
+	}
+
+	editor := strings.Fields(os.Getenv("VISUAL"))
+	if len(editor) == 0 {
+		editor = strings.Fields(os.Getenv("EDITOR"))
+	}
+	if len(editor) == 0 {
+		editor = []string{"vi"}
+	}
+
+	cmd := exec.Command(editor[0], append(editor[1:], path)...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to run %s: %w", editor[0], err)
+	}
+
+	// Report mistakes right away rather than on the next command
+	_, err = config.LoadFile(path)
+	return err
+}
+
+// configTemplate renders a config file listing every key, commented out
+func configTemplate() string {
+	var b strings.Builder
+	b.WriteString("# nvim-plugin settings, overridden by the environment variables in brackets\n")
+	for _, key := range config.Keys {
+		fmt.Fprintf(&b, "\n# %s (%s)\n# %s = \"\"\n", key.Description, key.Env, key.Name)
+	}
+	b.WriteString("\n# Profiles bundle settings selected with --profile or in the wizard, e.g.\n" +
+		"# [profiles.work]\n# author.email = \"\"\n# defaults.license = \"\"\n# defaults.host = \"\"\n")
+	return b.String()
+}
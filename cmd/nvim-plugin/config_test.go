@@ -0,0 +1,184 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/vintharas/nvim-plugin/pkg/config"
+	"github.com/vintharas/nvim-plugin/pkg/ui"
+)
+
+// withConfigHome points the config file to a temporary directory without
+// any environment variable override or git configuration
+func withConfigHome(t *testing.T) string {
+	t.Helper()
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+	t.Setenv("HOME", t.TempDir())
+	for _, key := range config.Keys {
+		t.Setenv(key.Env, "")
+		os.Unsetenv(key.Env)
+	}
+	return filepath.Join(configHome, "nvim-plugin", "config.toml")
+}
+
+func TestConfigSetGet(t *testing.T) {
+	path := withConfigHome(t)
+
+	if err := runConfig([]string{"set", "author.name", "Jane Doe"}); err != nil {
+		t.Fatalf("config set failed: %v", err)
+	}
+	if err := runConfig([]string{"set", "defaults.flavor", "vim"}); err != nil {
+		t.Fatalf("config set failed: %v", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("config set should create the config file: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := configGet(&out, []string{"author.name"}); err != nil || out.String() != "Jane Doe\n" {
+		t.Errorf("config get author.name = %q, %v", out.String(), err)
+	}
+
+	// Environment variables override the file without being saved
+	t.Setenv("NVIM_PLUGIN_FLAVOR", "lsp")
+	out.Reset()
+	if err := configGet(&out, nil); err != nil {
+		t.Fatalf("config get failed: %v", err)
+	}
+	for _, want := range []string{`author.name = "Jane Doe"`, `defaults.flavor = "lsp"`, `defaults.license = ""`} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("config get should list %s, got:\n%s", want, out.String())
+		}
+	}
+	if saved, _ := config.LoadFile(path); saved.Flavor != "vim" {
+		t.Errorf("Expected the saved flavor to stay vim, got %q", saved.Flavor)
+	}
+}
+
+func TestConfigProfiles(t *testing.T) {
+	withConfigHome(t)
+
+	if err := runConfig([]string{"set", "author.name", "Jane Doe"}); err != nil {
+		t.Fatalf("config set failed: %v", err)
+	}
+	if err := runConfig([]string{"set", "profiles.work.defaults.host", "git.corp.example"}); err != nil {
+		t.Fatalf("config set of a profile failed: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := configGet(&out, []string{"profiles.work.defaults.host"}); err != nil || out.String() != "git.corp.example\n" {
+		t.Errorf("config get profiles.work.defaults.host = %q, %v", out.String(), err)
+	}
+
+	// Profiles only list the settings they change
+	out.Reset()
+	if err := configGet(&out, nil); err != nil {
+		t.Fatalf("config get failed: %v", err)
+	}
+	if !strings.Contains(out.String(), `profiles.work.defaults.host = "git.corp.example"`) ||
+		strings.Contains(out.String(), "profiles.work.author.name") {
+		t.Errorf("config get should list the settings of the profile, got:\n%s", out.String())
+	}
+	// Unknown profiles are reported before anything is generated
+	if err := runNew([]string{"my-plugin", "--profile", "home"}); err == nil || !strings.Contains(err.Error(), "work") {
+		t.Errorf("new should reject unknown profiles, got %v", err)
+	}
+}
+
+func TestConfigErrors(t *testing.T) {
+	withConfigHome(t)
+
+	invalid := [][]string{
+		{},
+		{"unknown"},
+		{"get", "author.nmae"},
+		{"get", "author.name", "extra"},
+		{"set", "author.name"},
+		{"set", "author.nmae", "Jane"},
+		{"set", "defaults.flavor", "emacs"},
+		{"set", "defaults.test_framework", "jest"},
+		{"set", "github.user", "me me"},
+		{"set", "defaults.host", "https://git.example.com"},
+		{"set", "profiles.work.defaults.flavor", "emacs"},
+		{"set", "profiles.work.profiles.home.author.name", "Jane"},
+		{"get", "profiles.home.author.name"},
+	}
+	for _, args := range invalid {
+		if err := runConfig(args); err == nil {
+			t.Errorf("runConfig(%q) should have returned an error", args)
+		}
+	}
+}
+
+func TestConfigTemplate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte(configTemplate()), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The template is a valid config file setting nothing
+	cfg, err := config.LoadFile(path)
+	if err != nil || !reflect.DeepEqual(cfg, config.Config{}) {
+		t.Errorf("The config template should load as an empty config, got %+v, %v", cfg, err)
+	}
+
+	// Setting keys keeps the comments of the template, the profile example
+	// included
+	for key, value := range map[string]string{"author.name": "Jane Doe", "author.email": "jane@example.com", "defaults.host": "git.corp.example"} {
+		if err := config.SaveSetting(path, key, value); err != nil {
+			t.Fatalf("SaveSetting failed: %v", err)
+		}
+	}
+	data, _ := os.ReadFile(path)
+	for _, want := range []string{
+		"# Name of the plugin author (NVIM_PLUGIN_AUTHOR_NAME)\nauthor.name = \"Jane Doe\"\n",
+		"\nauthor.email = \"jane@example.com\"\n",
+		"\ndefaults.host = \"git.corp.example\"\n",
+		"# [profiles.work]\n# author.email = \"\"\n# defaults.license = \"\"\n# defaults.host = \"\"\n",
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("The config file should contain %q, got:\n%s", want, data)
+		}
+	}
+}
+
+func TestApplyConfig(t *testing.T) {
+	cfg := config.Config{
+		AuthorName:    "Jane Doe",
+		AuthorEmail:   "jane@example.com",
+		GitHubUser:    "jane",
+		License:       "Apache-2.0",
+		Flavor:        "vim",
+		OutputDir:     "/src",
+		TestFramework: "busted",
+		Host:          "git.corp.example",
+	}
+
+	spec := ui.PluginSpec{Name: "my-plugin"}
+	applyConfig(&spec, cfg)
+	want := ui.PluginSpec{
+		Name:          "my-plugin",
+		Author:        "Jane Doe <jane@example.com>",
+		GitHubUser:    "jane",
+		License:       "Apache-2.0",
+		Flavor:        "vim",
+		OutputDir:     "/src",
+		TestFramework: "busted",
+		Host:          "git.corp.example",
+	}
+	if !reflect.DeepEqual(spec, want) {
+		t.Errorf("applyConfig() = %+v, want %+v", spec, want)
+	}
+
+	// Flags take precedence over the settings
+	spec = ui.PluginSpec{Name: "my-plugin", License: "MIT", Flavor: "lua"}
+	applyConfig(&spec, cfg)
+	if spec.License != "MIT" || spec.Flavor != "lua" {
+		t.Errorf("applyConfig should keep the flags, got %+v", spec)
+	}
+}
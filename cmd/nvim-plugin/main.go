@@ -8,17 +8,72 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	// Import our UI package that contains the model and generator
 	"github.com/vintharas/nvim-plugin/pkg/ui"
+	// Import the user settings read from the config file
+	"github.com/vintharas/nvim-plugin/pkg/config"
 )
 
+// usage describes the available commands
+const usage = `Usage:
+  nvim-plugin                      Start the interactive wizard
+  nvim-plugin new [name] [flags]   Create a new plugin (interactive without a name)
+                                   --profile selects a profile of the config file
+  nvim-plugin config get [key]     Print a setting, or every setting without a key
+  nvim-plugin config set key value Change a setting in the config file
+  nvim-plugin config edit          Open the config file in $VISUAL or $EDITOR
+
+Run 'nvim-plugin new -h' to list the flags of the new command.
+`
+
 func main() {
-	// Initialize a new Bubble Tea program with our model
-	// Bubble Tea follows the Model-View-Update (MVU) architecture pattern
-	p := tea.NewProgram(ui.NewModel())
-	
-	// Run the program and handle any errors
-	// The program will run until a tea.Quit command is received
-	if _, err := p.Run(); err != nil {
-		fmt.Printf("Error running program: %v\n", err)
+	// Without arguments, start the interactive wizard
+	if len(os.Args) < 2 {
+		if err := runWizard(""); err != nil {
+			fmt.Printf("Error running program: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Otherwise dispatch to the requested command
+	if err := runCommand(os.Args[1], os.Args[2:]); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
-}
\ No newline at end of file
+}
+
+// runCommand runs a single CLI command with its arguments
+func runCommand(command string, args []string) error {
+	switch command {
+	case "new":
+		return runNew(args)
+	case "config":
+		return runConfig(args)
+	case "help", "-h", "--help":
+		fmt.Print(usage)
+		return nil
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		return fmt.Errorf("unknown command %q", command)
+	}
+}
+
+// runWizard starts the interactive Bubble Tea wizard
+// Without a profile, the wizard asks for one when the config file defines some
+func runWizard(profile string) error {
+	// The wizard starts from the defaults of the user settings
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if cfg, err = cfg.WithProfile(profile); err != nil {
+		return err
+	}
+
+	// Initialize a new Bubble Tea program with our model
+	// Bubble Tea follows the Model-View-Update (MVU) architecture pattern
+	p := tea.NewProgram(ui.NewModelWithConfig(cfg))
+
+	// Run the program until a tea.Quit command is received
+	_, err = p.Run()
+	return err
+}
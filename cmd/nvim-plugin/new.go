@@ -0,0 +1,242 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/vintharas/nvim-plugin/pkg/config"
+	"github.com/vintharas/nvim-plugin/pkg/ui"
+)
+
+// optionList collects the repeatable --option flag into an option schema
+type optionList []ui.Option
+
+// String implements flag.Value
+func (l *optionList) String() string {
+	names := make([]string, 0, len(*l))
+	for _, option := range *l {
+		names = append(names, option.Name)
+	}
+	return strings.Join(names, ",")
+}
+
+// Set implements flag.Value by parsing one option declaration
+func (l *optionList) Set(value string) error {
+	option, err := ui.ParseOption(value)
+	if err != nil {
+		return err
+	}
+	*l = append(*l, option)
+	return nil
+}
+
+// actionList collects the repeatable --action flag
+type actionList []ui.Action
+
+// String implements flag.Value
+func (l *actionList) String() string {
+	names := make([]string, 0, len(*l))
+	for _, action := range *l {
+		names = append(names, action.Name)
+	}
+	return strings.Join(names, ",")
+}
+
+// Set implements flag.Value by parsing one action declaration
+func (l *actionList) Set(value string) error {
+	action, err := ui.ParseAction(value)
+	if err != nil {
+		return err
+	}
+	*l = append(*l, action)
+	return nil
+}
+
+// parseNewArgs parses the arguments of the new command into a plugin spec and
+// the profile selected with --profile
+// The plugin name may appear before or after the flags
+func parseNewArgs(args []string) (ui.PluginSpec, string, error) {
+	var spec ui.PluginSpec
+	var options optionList
+	var actions actionList
+	var extensions, installs, profile string
+
+	fs := flag.NewFlagSet("new", flag.ContinueOnError)
+	fs.StringVar(&spec.Description, "description", "", "short description of the plugin")
+	fs.StringVar(&spec.RepoName, "repo-name", "", "name of the plugin directory and repository (default: the plugin name)")
+	fs.StringVar(&spec.ModuleName, "module", "", "Lua module required by users (default: the name without a .nvim or .vim suffix), checked against the plugins of the Neovim data directory")
+	fs.StringVar(&spec.VarName, "var-name", "", "identifier used in Lua and Vim script variables (default: derived from the module)")
+	fs.StringVar(&spec.CommandName, "command", "", "user command of the plugin (default: the module in PascalCase)")
+	fs.StringVar(&spec.Flavor, "flavor", "", "template set: "+strings.Join(ui.FlavorNames(), ", "))
+	fs.StringVar(&spec.Filetype, "filetype", "", "filetype added by the filetype flavor (default: derived from the name)")
+	fs.StringVar(&extensions, "extensions", "", "comma-separated file extensions detected as the filetype (default: the filetype)")
+	fs.Var(&options, "option", "declare an option as name:type[:default[:description]] (repeatable)")
+	fs.BoolVar(&spec.Autocmds, "autocmds", false, "generate an autocmds module with an augroup and a User <Name>Ready event")
+	fs.Var(&actions, "action", "declare a <Plug> mapping as name[:keys[:description]] (repeatable)")
+	fs.StringVar(&spec.Owner, "owner", "", "user or organization owning the repository (default: from the git remote of an existing clone)")
+	fs.StringVar(&spec.Host, "host", "", "host of the repository (default: github.com)")
+	fs.StringVar(&spec.Author, "author", "", "author of the plugin, e.g. \"Jane Doe <jane@example.com>\"")
+	fs.StringVar(&spec.License, "license", "", "SPDX identifier of the license, written to LICENSE for "+strings.Join(ui.LicenseIDs(), ", ")+" (default: MIT)")
+	fs.BoolVar(&spec.Rockspec, "rockspec", false, "generate a luarocks rockspec, a .busted file and a release workflow (flavors with a Lua module)")
+	fs.StringVar(&spec.OutputDir, "output-dir", "", "directory the plugin is created in (default: the current directory)")
+	fs.StringVar(&spec.TestFramework, "test-framework", "", "framework the tests run with: "+strings.Join(ui.TestFrameworkNames(), ", ")+" (default: plenary)")
+	fs.BoolVar(&spec.GitInit, "git", false, "initialize a git repository, add the origin remote from the host and owner, and commit the generated files")
+	fs.StringVar(&profile, "profile", "", "profile of the config file applied on top of the other settings")
+	fs.StringVar(&installs, "install", "", "comma-separated plugin managers to document: "+strings.Join(ui.PluginManagerNames(), ", ")+" (default: all)")
+
+	if err := fs.Parse(args); err != nil {
+		return spec, profile, err
+	}
+
+	// Allow flags after the plugin name, e.g. `new my-plugin --description "..."`
+	if fs.NArg() > 0 {
+		spec.Name = fs.Arg(0)
+		if err := fs.Parse(fs.Args()[1:]); err != nil {
+			return spec, profile, err
+		}
+		if fs.NArg() > 0 {
+			return spec, profile, fmt.Errorf("unexpected arguments: %s", strings.Join(fs.Args(), " "))
+		}
+	}
+
+	// Without a name the wizard starts, which only takes the profile
+	if spec.Name == "" {
+		var ignored []string
+		fs.Visit(func(f *flag.Flag) {
+			if f.Name != "profile" {
+				ignored = append(ignored, "--"+f.Name)
+			}
+		})
+		if len(ignored) > 0 {
+			return spec, profile, fmt.Errorf("missing plugin name for %s: run nvim-plugin new <name> [flags], or without flags for the wizard", strings.Join(ignored, ", "))
+		}
+	}
+
+	// Reject names that are unsafe as a directory or invalid as identifiers
+	if spec.Name != "" {
+		if err := ui.ValidateName(spec.Name); err != nil {
+			return spec, profile, err
+		}
+		if _, err := spec.Names(); err != nil {
+			return spec, profile, err
+		}
+	}
+
+	// Catch typos in the flavor and unsupported options before anything is generated
+	flavor, err := ui.LookupFlavor(spec.Flavor)
+	if err != nil {
+		return spec, profile, err
+	}
+	if err := flavor.ValidateOptions(options); err != nil {
+		return spec, profile, err
+	}
+
+	// The generator validates the filetype itself, extensions only need splitting
+	if extensions != "" {
+		parsed, err := ui.ParseExtensions(extensions)
+		if err != nil {
+			return spec, profile, err
+		}
+		spec.Extensions = parsed
+	}
+
+	// Catch typos in the repository and plugin managers as well
+	if spec.Host != "" {
+		if err := ui.ValidateHost(spec.Host); err != nil {
+			return spec, profile, err
+		}
+	}
+	if spec.Owner != "" {
+		if err := ui.ValidateOwner(spec.Owner); err != nil {
+			return spec, profile, err
+		}
+	}
+	if spec.TestFramework != "" {
+		if err := ui.ValidateTestFramework(spec.TestFramework); err != nil {
+			return spec, profile, err
+		}
+	}
+	if installs != "" {
+		parsed, err := ui.ParsePluginManagers(installs)
+		if err != nil {
+			return spec, profile, err
+		}
+		spec.Installs = parsed
+	}
+
+	spec.Options = options
+	spec.Actions = actions
+	return spec, profile, nil
+}
+
+// runNew creates a plugin directly from the command line
+// Without a plugin name it falls back to the interactive wizard
+func runNew(args []string) error {
+	spec, profile, err := parseNewArgs(args)
+	// -h prints the usage of the flags, which is not an error
+	if errors.Is(err, flag.ErrHelp) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if spec.Name == "" {
+		return runWizard(profile)
+	}
+
+	// Flags take precedence over the user settings and the profile
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if cfg, err = cfg.WithProfile(profile); err != nil {
+		return err
+	}
+	applyConfig(&spec, cfg)
+
+	// Collisions do not prevent generating the plugin, but are worth knowing
+	names, err := spec.Names()
+	if err != nil {
+		return err
+	}
+	for _, warning := range ui.ModuleWarnings(names.Module) {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+	if _, ok := ui.LookupLicense(spec.License); !ok && spec.License != "" {
+		fmt.Fprintf(os.Stderr, "Warning: no built-in text for the license %q, add the LICENSE file yourself\n", spec.License)
+	}
+
+	if err := ui.Generate(spec); err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Plugin created successfully at %s\n", ui.PluginDir(spec.OutputDir, names.Repo))
+	return nil
+}
+
+// applyConfig fills the settings left unset by the flags from the user settings
+func applyConfig(spec *ui.PluginSpec, cfg config.Config) {
+	if spec.Author == "" {
+		spec.Author = cfg.Author()
+	}
+	if spec.License == "" {
+		spec.License = cfg.License
+	}
+	if spec.Flavor == "" {
+		spec.Flavor = cfg.Flavor
+	}
+	if spec.OutputDir == "" {
+		spec.OutputDir = config.ExpandPath(cfg.OutputDir)
+	}
+	if spec.TestFramework == "" {
+		spec.TestFramework = cfg.TestFramework
+	}
+	if spec.Host == "" {
+		spec.Host = cfg.Host
+	}
+	spec.GitHubUser = cfg.GitHubUser
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/vintharas/nvim-plugin/pkg/ui"
+)
+
+func TestParseNewArgs(t *testing.T) {
+	args := []string{
+		"my-plugin",
+		"--description", "A test plugin",
+		"--flavor", "colorscheme",
+		"--option", "enabled:boolean:true:Enable the plugin",
+		"--option", "width:number:80",
+	}
+
+	spec, _, err := parseNewArgs(args)
+	if err != nil {
+		t.Fatalf("parseNewArgs failed: %v", err)
+	}
+
+	if spec.Name != "my-plugin" {
+		t.Errorf("Expected name 'my-plugin', got %q", spec.Name)
+	}
+	if spec.Description != "A test plugin" {
+		t.Errorf("Expected description 'A test plugin', got %q", spec.Description)
+	}
+	if spec.Flavor != "colorscheme" {
+		t.Errorf("Expected flavor 'colorscheme', got %q", spec.Flavor)
+	}
+
+	expected := []ui.Option{
+		{Name: "enabled", Type: "boolean", Default: "true", Description: "Enable the plugin"},
+		{Name: "width", Type: "number", Default: "80", Description: "TODO: describe width"},
+	}
+	if len(spec.Options) != len(expected) {
+		t.Fatalf("Expected %d options, got %d", len(expected), len(spec.Options))
+	}
+	for i, option := range expected {
+		if spec.Options[i] != option {
+			t.Errorf("Option %d: expected %+v, got %+v", i, option, spec.Options[i])
+		}
+	}
+
+	// Flags may also come before the plugin name
+	spec, _, err = parseNewArgs([]string{"--description", "Flags first", "other-plugin"})
+	if err != nil {
+		t.Fatalf("parseNewArgs with flags first failed: %v", err)
+	}
+	if spec.Name != "other-plugin" || spec.Description != "Flags first" {
+		t.Errorf("Unexpected spec with flags first: %+v", spec)
+	}
+}
+
+func TestParseNewArgsFiletype(t *testing.T) {
+	spec, _, err := parseNewArgs([]string{"mylang.nvim", "--flavor", "filetype", "--filetype", "mylang", "--extensions", ".ml, mli"})
+	if err != nil {
+		t.Fatalf("parseNewArgs failed: %v", err)
+	}
+
+	if spec.Filetype != "mylang" {
+		t.Errorf("Expected filetype 'mylang', got %q", spec.Filetype)
+	}
+	if len(spec.Extensions) != 2 || spec.Extensions[0] != "ml" || spec.Extensions[1] != "mli" {
+		t.Errorf("Expected extensions [ml mli], got %q", spec.Extensions)
+	}
+}
+
+func TestParseNewArgsActions(t *testing.T) {
+	spec, _, err := parseNewArgs([]string{"my-plugin", "--action", "toggle:<leader>tt:Toggle it", "--action", "open"})
+	if err != nil {
+		t.Fatalf("parseNewArgs failed: %v", err)
+	}
+
+	expected := []ui.Action{
+		{Name: "toggle", Keys: "<leader>tt", Description: "Toggle it"},
+		{Name: "open", Description: "TODO: describe open"},
+	}
+	if len(spec.Actions) != len(expected) {
+		t.Fatalf("Expected %d actions, got %d", len(expected), len(spec.Actions))
+	}
+	for i, action := range expected {
+		if spec.Actions[i] != action {
+			t.Errorf("Action %d: expected %+v, got %+v", i, action, spec.Actions[i])
+		}
+	}
+}
+
+func TestParseNewArgsRepository(t *testing.T) {
+	spec, _, err := parseNewArgs([]string{"my-plugin", "--owner", "me", "--host", "gitlab.com", "--install", "pack, lazy"})
+	if err != nil {
+		t.Fatalf("parseNewArgs failed: %v", err)
+	}
+
+	if spec.Owner != "me" || spec.Host != "gitlab.com" {
+		t.Errorf("Expected repository gitlab.com/me, got %s/%s", spec.Host, spec.Owner)
+	}
+	if len(spec.Installs) != 2 || spec.Installs[0] != "lazy" || spec.Installs[1] != "pack" {
+		t.Errorf("Expected plugin managers [lazy pack], got %q", spec.Installs)
+	}
+}
+
+func TestParseNewArgsRockspec(t *testing.T) {
+	spec, _, err := parseNewArgs([]string{"my-plugin", "--rockspec", "--author", "Jane Doe <jane@example.com>", "--license", "Apache-2.0"})
+	if err != nil {
+		t.Fatalf("parseNewArgs failed: %v", err)
+	}
+
+	if !spec.Rockspec {
+		t.Errorf("Expected a rockspec")
+	}
+	if spec.Author != "Jane Doe <jane@example.com>" || spec.License != "Apache-2.0" {
+		t.Errorf("Expected the author and license, got %q and %q", spec.Author, spec.License)
+	}
+}
+
+func TestParseNewArgsProfile(t *testing.T) {
+	spec, profile, err := parseNewArgs([]string{"my-plugin", "--profile", "work"})
+	if err != nil {
+		t.Fatalf("parseNewArgs failed: %v", err)
+	}
+	if spec.Name != "my-plugin" || profile != "work" {
+		t.Errorf("Expected the plugin my-plugin and the profile work, got %q and %q", spec.Name, profile)
+	}
+}
+
+func TestParseNewArgsWizard(t *testing.T) {
+	// Without a name, the wizard starts with the profile
+	spec, profile, err := parseNewArgs([]string{"--profile", "work"})
+	if err != nil {
+		t.Fatalf("parseNewArgs failed: %v", err)
+	}
+	if spec.Name != "" || profile != "work" {
+		t.Errorf("Expected no plugin name and the profile work, got %q and %q", spec.Name, profile)
+	}
+}
+
+func TestRunNewHelp(t *testing.T) {
+	// The usage of the flags is printed on request, which is not an error
+	if err := runNew([]string{"-h"}); err != nil {
+		t.Errorf("runNew(-h) should succeed, got %v", err)
+	}
+}
+
+func TestParseNewArgsGit(t *testing.T) {
+	spec, _, err := parseNewArgs([]string{"my-plugin", "--git"})
+	if err != nil {
+		t.Fatalf("parseNewArgs failed: %v", err)
+	}
+	if !spec.GitInit {
+		t.Errorf("Expected --git to initialize a repository")
+	}
+}
+
+func TestParseNewArgsNames(t *testing.T) {
+	spec, _, err := parseNewArgs([]string{"foo.nvim", "--module", "foo_core", "--command", "Foo"})
+	if err != nil {
+		t.Fatalf("parseNewArgs failed: %v", err)
+	}
+
+	names, err := spec.Names()
+	if err != nil {
+		t.Fatalf("Names failed: %v", err)
+	}
+	want := ui.Names{Repo: "foo.nvim", Module: "foo_core", VarName: "foo_core", Command: "Foo"}
+	if names != want {
+		t.Errorf("Expected names %+v, got %+v", want, names)
+	}
+}
+
+func TestParseNewArgsErrors(t *testing.T) {
+	invalid := [][]string{
+		{"my-plugin", "--option", "enabled:bool"},
+		{"my-plugin", "--option", "a:boolean", "--option", "a:number"},
+		{"my-plugin", "extra-argument"},
+		{"my-plugin", "--unknown"},
+		{"my-plugin", "--flavor", "emacs"},
+		{"my-plugin", "--extensions", "a/b"},
+		{"my-plugin", "--flavor", "vim", "--option", "filetypes:table"},
+		{"my-plugin", "--action", "open file"},
+		{"my-plugin", "--owner", "me me"},
+		{"../my-plugin"},
+		{"my plugin"},
+		{"my-plugin", "--host", "https://github.com"},
+		{"my-plugin", "--install", "lazy,dein"},
+		{"my-plugin", "--module", "my.plugin"},
+		{"my-plugin", "--var-name", "my-plugin"},
+		{"my-plugin", "--command", "My-plugin"},
+		{"man.nvim"},
+		{"日本"},
+		// Flags other than --profile need a plugin name
+		{"--flavor", "vim"},
+		{"--profile", "work", "--git"},
+	}
+
+	for _, args := range invalid {
+		if _, _, err := parseNewArgs(args); err == nil {
+			t.Errorf("parseNewArgs(%q) should have returned an error", args)
+		}
+	}
+}
@@ -0,0 +1,300 @@
+// Package config reads and writes the user settings of nvim-plugin, stored
+// in $XDG_CONFIG_HOME/nvim-plugin/config.toml and overridden by environment
+// variables. Named profiles bundle settings applied together, e.g. for work
+// and personal plugins
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Config holds the user settings applied to every generated plugin
+type Config struct {
+	AuthorName    string // Name of the plugin author
+	AuthorEmail   string // Email address of the plugin author
+	GitHubUser    string // GitHub user owning the plugin repositories
+	License       string // SPDX identifier of the default license
+	Flavor        string // Default template set
+	OutputDir     string // Directory the plugins are created in
+	TestFramework string // Framework the generated tests run with
+	Host          string // Host of the plugin repositories, github.com when empty
+
+	Profile  string            // Name of the active profile, empty for none
+	Profiles map[string]Config // Profiles of the config file by name
+}
+
+// Key describes a setting of the config file
+type Key struct {
+	Name        string // Dotted key in the config file, e.g. "author.name"
+	Env         string // Environment variable overriding the setting
+	Description string // One-line description shown by `config get`
+}
+
+// Keys lists every setting of the config file, in file order
+var Keys = []Key{
+	{Name: "author.name", Env: "NVIM_PLUGIN_AUTHOR_NAME", Description: "Name of the plugin author"},
+	{Name: "author.email", Env: "NVIM_PLUGIN_AUTHOR_EMAIL", Description: "Email address of the plugin author"},
+	{Name: "github.user", Env: "NVIM_PLUGIN_GITHUB_USER", Description: "GitHub user owning the plugin repositories"},
+	{Name: "defaults.license", Env: "NVIM_PLUGIN_LICENSE", Description: "SPDX identifier of the license"},
+	{Name: "defaults.flavor", Env: "NVIM_PLUGIN_FLAVOR", Description: "Template set of new plugins"},
+	{Name: "defaults.output_dir", Env: "NVIM_PLUGIN_OUTPUT_DIR", Description: "Directory new plugins are created in"},
+	{Name: "defaults.test_framework", Env: "NVIM_PLUGIN_TEST_FRAMEWORK", Description: "Framework the generated tests run with"},
+	{Name: "defaults.host", Env: "NVIM_PLUGIN_HOST", Description: "Host of the plugin repositories"},
+}
+
+// profilePrefix starts the keys of the profiles, e.g. "profiles.work.author.name"
+const profilePrefix = "profiles."
+
+// field returns the setting stored under a key, nil for unknown keys
+func (c *Config) field(key string) *string {
+	switch key {
+	case "author.name":
+		return &c.AuthorName
+	case "author.email":
+		return &c.AuthorEmail
+	case "github.user":
+		return &c.GitHubUser
+	case "defaults.license":
+		return &c.License
+	case "defaults.flavor":
+		return &c.Flavor
+	case "defaults.output_dir":
+		return &c.OutputDir
+	case "defaults.test_framework":
+		return &c.TestFramework
+	case "defaults.host":
+		return &c.Host
+	}
+	return nil
+}
+
+// splitProfileKey splits the key of a profile setting into the name of the
+// profile and the key of the setting, e.g. "work" and "author.name"
+func splitProfileKey(key string) (profile, setting string, ok bool) {
+	if !strings.HasPrefix(key, profilePrefix) {
+		return "", "", false
+	}
+	return strings.Cut(strings.TrimPrefix(key, profilePrefix), ".")
+}
+
+// Get returns the value of a setting, or of a profile setting
+func (c Config) Get(key string) (string, error) {
+	if name, setting, ok := splitProfileKey(key); ok {
+		profile, found := c.Profiles[name]
+		if !found {
+			return "", c.unknownProfile(name)
+		}
+		return profile.Get(setting)
+	}
+
+	field := c.field(key)
+	if field == nil {
+		return "", unknownKey(key)
+	}
+	return *field, nil
+}
+
+// Set changes the value of a setting, or of a profile setting, creating the
+// profile when needed
+func (c *Config) Set(key, value string) error {
+	if name, setting, ok := splitProfileKey(key); ok {
+		if name == "" {
+			return fmt.Errorf("invalid config key %q: the profile name is empty", key)
+		}
+		// Profiles hold settings, not profiles of their own
+		profile := c.Profiles[name]
+		if profile.field(setting) == nil {
+			return unknownKey(setting)
+		}
+		if err := profile.Set(setting, value); err != nil {
+			return err
+		}
+		if c.Profiles == nil {
+			c.Profiles = map[string]Config{}
+		}
+		c.Profiles[name] = profile
+		return nil
+	}
+
+	field := c.field(key)
+	if field == nil {
+		return unknownKey(key)
+	}
+	*field = value
+	return nil
+}
+
+// unknownKey explains that a key is not a setting of the config file
+func unknownKey(key string) error {
+	names := make([]string, 0, len(Keys))
+	for _, k := range Keys {
+		names = append(names, k.Name)
+	}
+	return fmt.Errorf("unknown config key %q: must be one of %s, or profiles.<name>.<key>", key, strings.Join(names, ", "))
+}
+
+// unknownProfile explains that a profile is not defined in the config file
+func (c Config) unknownProfile(name string) error {
+	names := c.ProfileNames()
+	if len(names) == 0 {
+		return fmt.Errorf("unknown profile %q: the config file defines no [profiles.%s] table", name, name)
+	}
+	return fmt.Errorf("unknown profile %q: must be one of %s", name, strings.Join(names, ", "))
+}
+
+// ProfileNames returns the names of the profiles in alphabetical order
+func (c Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// WithProfile returns the settings with those of a profile taking precedence,
+// and the environment variables still overriding both. An empty name keeps
+// the settings as they are
+func (c Config) WithProfile(name string) (Config, error) {
+	if name == "" {
+		return c, nil
+	}
+	profile, ok := c.Profiles[name]
+	if !ok {
+		return c, c.unknownProfile(name)
+	}
+
+	for _, key := range Keys {
+		if value := *profile.field(key.Name); value != "" {
+			*c.field(key.Name) = value
+		}
+	}
+	c.Profile = name
+	c.applyEnv()
+	return c, nil
+}
+
+// Author renders the author as "Name <email>", or whichever part is set
+func (c Config) Author() string {
+	switch {
+	case c.AuthorName != "" && c.AuthorEmail != "":
+		return c.AuthorName + " <" + c.AuthorEmail + ">"
+	case c.AuthorEmail != "":
+		return "<" + c.AuthorEmail + ">"
+	}
+	return c.AuthorName
+}
+
+// Path returns the location of the config file:
+// $XDG_CONFIG_HOME/nvim-plugin/config.toml, defaulting to ~/.config
+func Path() (string, error) {
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to locate the config file: %w", err)
+		}
+		configHome = filepath.Join(home, ".config")
+	}
+	return filepath.Join(configHome, "nvim-plugin", "config.toml"), nil
+}
+
+// Load reads the config file, if there is one, falls back to the git
+// configuration for the author and GitHub user, and applies the environment
+// variable overrides
+func Load() (Config, error) {
+	path, err := Path()
+	if err != nil {
+		return Config{}, err
+	}
+	cfg, err := LoadFile(path)
+	if err != nil {
+		return Config{}, err
+	}
+	cfg.applyGit()
+	cfg.applyEnv()
+	return cfg, nil
+}
+
+// LoadFile reads a config file, a missing file being an empty config
+func LoadFile(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Config{}, nil
+	}
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to read config file: %w", err)
+	}
+	return parseConfig(path, string(data))
+}
+
+// parseConfig reads the settings from the contents of the config file at path
+func parseConfig(path, data string) (Config, error) {
+	var cfg Config
+	values, err := decodeTOML(data)
+	if err != nil {
+		return cfg, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	for key, value := range values {
+		if err := cfg.Set(key, value); err != nil {
+			return cfg, fmt.Errorf("invalid config file %s: %w", path, err)
+		}
+	}
+	return cfg, nil
+}
+
+// applyEnv overrides the settings with the environment variables that are set
+func (c *Config) applyEnv() {
+	for _, key := range Keys {
+		if value, ok := os.LookupEnv(key.Env); ok {
+			*c.field(key.Name) = value
+		}
+	}
+}
+
+// SaveSetting changes a setting, or a profile setting, of a config file in
+// place, keeping its comments and other settings, and creating the file and
+// its directory when needed
+func SaveSetting(path, key, value string) error {
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	cfg, err := parseConfig(path, string(data))
+	if err != nil {
+		return err
+	}
+	if err := cfg.Set(key, value); err != nil {
+		return err
+	}
+
+	updated, err := setTOML(string(data), key, value)
+	if err != nil {
+		return fmt.Errorf("failed to update config file %s: %w", path, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(updated), 0o644); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
+
+// ExpandPath expands a leading ~ to the home directory, as in the output_dir setting
+func ExpandPath(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[1:])
+}
@@ -0,0 +1,249 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPath(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "/config")
+	if got, err := Path(); err != nil || got != filepath.Join("/config", "nvim-plugin", "config.toml") {
+		t.Errorf("Path() = %q, %v", got, err)
+	}
+
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("HOME", "/home/me")
+	if got, err := Path(); err != nil || got != filepath.Join("/home/me", ".config", "nvim-plugin", "config.toml") {
+		t.Errorf("Path() without XDG_CONFIG_HOME = %q, %v", got, err)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+	// Keep the git configuration of the user out of the test
+	t.Setenv("HOME", t.TempDir())
+	// Unset the overrides, t.Setenv restores them after the test
+	for _, key := range Keys {
+		t.Setenv(key.Env, "")
+		os.Unsetenv(key.Env)
+	}
+
+	// Without a config file every setting is empty
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load without a config file failed: %v", err)
+	}
+	if !reflect.DeepEqual(cfg, Config{}) {
+		t.Errorf("Expected an empty config, got %+v", cfg)
+	}
+
+	path := filepath.Join(configHome, "nvim-plugin", "config.toml")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	data := "[author]\nname = \"Jane Doe\"\nemail = \"jane@example.com\"\n\n[defaults]\nlicense = \"Apache-2.0\"\n"
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Environment variables override the file
+	t.Setenv("NVIM_PLUGIN_LICENSE", "MIT")
+	t.Setenv("NVIM_PLUGIN_FLAVOR", "vim")
+	cfg, err = Load()
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	want := Config{AuthorName: "Jane Doe", AuthorEmail: "jane@example.com", License: "MIT", Flavor: "vim"}
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("Load() = %+v, want %+v", cfg, want)
+	}
+	if got := cfg.Author(); got != "Jane Doe <jane@example.com>" {
+		t.Errorf("Author() = %q", got)
+	}
+
+	// Unknown keys are reported with the path of the file
+	if err := os.WriteFile(path, []byte("[author]\nnmae = \"typo\"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), "author.nmae") {
+		t.Errorf("Load should reject unknown keys, got %v", err)
+	}
+}
+
+func TestSaveSetting(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nvim-plugin", "config.toml")
+
+	var cfg Config
+	if err := cfg.Set("unknown", "value"); err == nil {
+		t.Errorf("Set should reject unknown keys")
+	}
+	if err := SaveSetting(path, "unknown", "value"); err == nil {
+		t.Errorf("SaveSetting should reject unknown keys")
+	}
+
+	for _, setting := range [][2]string{{"github.user", "jane"}, {"defaults.output_dir", "~/src"}} {
+		if err := cfg.Set(setting[0], setting[1]); err != nil {
+			t.Fatalf("Set failed: %v", err)
+		}
+		if err := SaveSetting(path, setting[0], setting[1]); err != nil {
+			t.Fatalf("SaveSetting failed: %v", err)
+		}
+	}
+
+	loaded, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile failed: %v", err)
+	}
+	if !reflect.DeepEqual(loaded, cfg) {
+		t.Errorf("LoadFile() = %+v, want %+v", loaded, cfg)
+	}
+	if value, err := loaded.Get("github.user"); err != nil || value != "jane" {
+		t.Errorf("Get(github.user) = %q, %v", value, err)
+	}
+
+	// Comments and other settings of the file are kept
+	path = writeConfig(t, "# My settings\n[author]\nname = \"Jane\" # full name\n")
+	if err := SaveSetting(path, "author.name", "Jane Doe"); err != nil {
+		t.Fatalf("SaveSetting failed: %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "# My settings\n[author]\nname = \"Jane Doe\" # full name\n" {
+		t.Errorf("SaveSetting should only change the value, got:\n%s", data)
+	}
+}
+
+func TestProfiles(t *testing.T) {
+	for _, key := range Keys {
+		t.Setenv(key.Env, "")
+		os.Unsetenv(key.Env)
+	}
+
+	path := filepath.Join(t.TempDir(), "config.toml")
+	data := `[author]
+name = "Jane Doe"
+email = "jane@example.com"
+
+[defaults]
+license = "MIT"
+flavor = "lua"
+
+[profiles.work.author]
+email = "jane.doe@corp.example"
+
+[profiles.work.defaults]
+license = "Apache-2.0"
+output_dir = "~/work"
+host = "git.corp.example"
+
+[profiles.oss]
+defaults.flavor = "mixed"
+`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile failed: %v", err)
+	}
+	if got := cfg.ProfileNames(); !reflect.DeepEqual(got, []string{"oss", "work"}) {
+		t.Errorf("ProfileNames() = %v", got)
+	}
+	if value, err := cfg.Get("profiles.work.defaults.host"); err != nil || value != "git.corp.example" {
+		t.Errorf("Get(profiles.work.defaults.host) = %q, %v", value, err)
+	}
+
+	// The settings of the profile take precedence, the others are kept
+	work, err := cfg.WithProfile("work")
+	if err != nil {
+		t.Fatalf("WithProfile failed: %v", err)
+	}
+	want := Config{
+		AuthorName:  "Jane Doe",
+		AuthorEmail: "jane.doe@corp.example",
+		License:     "Apache-2.0",
+		Flavor:      "lua",
+		OutputDir:   "~/work",
+		Host:        "git.corp.example",
+		Profile:     "work",
+		Profiles:    cfg.Profiles,
+	}
+	if !reflect.DeepEqual(work, want) {
+		t.Errorf("WithProfile(work) = %+v, want %+v", work, want)
+	}
+
+	// Environment variables still override the profile
+	t.Setenv("NVIM_PLUGIN_LICENSE", "MPL-2.0")
+	if work, _ := cfg.WithProfile("work"); work.License != "MPL-2.0" {
+		t.Errorf("Expected the environment to override the profile, got %q", work.License)
+	}
+
+	if _, err := cfg.WithProfile("home"); err == nil || !strings.Contains(err.Error(), "oss, work") {
+		t.Errorf("WithProfile should reject unknown profiles, got %v", err)
+	}
+	if _, err := LoadFile(writeConfig(t, "[profiles.work]\nauthor.nmae = \"typo\"\n")); err == nil || !strings.Contains(err.Error(), "author.nmae") {
+		t.Errorf("LoadFile should reject unknown profile keys, got %v", err)
+	}
+
+	// Profile settings are saved in their table
+	if err := cfg.Set("profiles.oss.github.user", "jane"); err != nil {
+		t.Fatalf("Set failed: %v", err)
+	}
+	if err := SaveSetting(path, "profiles.oss.github.user", "jane"); err != nil {
+		t.Fatalf("SaveSetting failed: %v", err)
+	}
+	loaded, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile failed: %v", err)
+	}
+	if !reflect.DeepEqual(loaded, cfg) {
+		t.Errorf("LoadFile() = %+v, want %+v", loaded, cfg)
+	}
+}
+
+// writeConfig writes a config file to a temporary directory
+func writeConfig(t *testing.T, data string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestAuthor(t *testing.T) {
+	tests := []struct {
+		cfg  Config
+		want string
+	}{
+		{Config{}, ""},
+		{Config{AuthorName: "Jane"}, "Jane"},
+		{Config{AuthorEmail: "jane@example.com"}, "<jane@example.com>"},
+		{Config{AuthorName: "Jane", AuthorEmail: "jane@example.com"}, "Jane <jane@example.com>"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.cfg.Author(); got != tt.want {
+			t.Errorf("%+v.Author() = %q, want %q", tt.cfg, got, tt.want)
+		}
+	}
+}
+
+func TestExpandPath(t *testing.T) {
+	t.Setenv("HOME", "/home/me")
+	tests := map[string]string{
+		"~":          "/home/me",
+		"~/src":      filepath.Join("/home/me", "src"),
+		"/abs":       "/abs",
+		"rel/dir":    "rel/dir",
+		"~other/dir": "~other/dir",
+	}
+	for input, want := range tests {
+		if got := ExpandPath(input); got != want {
+			t.Errorf("ExpandPath(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
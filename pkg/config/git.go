@@ -0,0 +1,69 @@
+package config
+
+import (
+	"errors"
+	"os/exec"
+	"regexp"
+	"strings"
+)
+
+// gitSettings maps the keys of the git configuration to the settings they
+// provide when the config file leaves them unset
+var gitSettings = []struct {
+	gitKey string
+	key    string
+}{
+	{gitKey: "user.name", key: "author.name"},
+	{gitKey: "user.email", key: "author.email"},
+	{gitKey: "github.user", key: "github.user"},
+}
+
+// applyGit fills the author and GitHub user left unset with the values of
+// the git configuration. Without git, they stay unset
+func (c *Config) applyGit() {
+	values, err := runGitConfig()
+	if err != nil {
+		return
+	}
+
+	for _, setting := range gitSettings {
+		if field := c.field(setting.key); *field == "" {
+			*field = values[setting.gitKey]
+		}
+	}
+}
+
+// runGitConfig asks git for the values of gitSettings, so that includes,
+// conditional includes and the system configuration apply as they do for
+// git. The configuration of a repository in the working directory is left
+// out. It fails when git is not installed
+func runGitConfig() (map[string]string, error) {
+	git, err := exec.LookPath("git")
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(gitSettings))
+	for _, setting := range gitSettings {
+		keys = append(keys, regexp.QuoteMeta(setting.gitKey))
+	}
+	pattern := "^(" + strings.Join(keys, "|") + ")$"
+
+	values := map[string]string{}
+	// The global configuration takes precedence over the system one
+	for _, scope := range []string{"--system", "--global"} {
+		output, err := exec.Command(git, "config", scope, "--includes", "--get-regexp", pattern).Output()
+		// git exits with 1 when no key matches
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+			key, value, _ := strings.Cut(line, " ")
+			values[strings.ToLower(key)] = value
+		}
+	}
+	return values, nil
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestApplyGit(t *testing.T) {
+	home := t.TempDir()
+	configHome := filepath.Join(home, ".config")
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+	t.Setenv("GIT_CONFIG_SYSTEM", filepath.Join(home, "missing"))
+
+	if err := os.MkdirAll(filepath.Join(configHome, "git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	xdgConfig := "[user]\n\tname = XDG Name\n\temail = xdg@example.com\n[github]\n\tuser = xdg\n"
+	if err := os.WriteFile(filepath.Join(configHome, "git", "config"), []byte(xdgConfig), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	gitconfig := "[user]\n\tname = Jane Doe\n[include]\n\tpath = ~/.gitconfig-work\n"
+	if err := os.WriteFile(filepath.Join(home, ".gitconfig"), []byte(gitconfig), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(home, ".gitconfig-work"), []byte("[user]\n\temail = jane@corp.example\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// ~/.gitconfig is read last, so its values win as they do for git,
+	// including those of the files it includes
+	if _, err := exec.LookPath("git"); err == nil {
+		want := Config{AuthorName: "Jane Doe", AuthorEmail: "jane@corp.example", GitHubUser: "jane"}
+		cfg := Config{GitHubUser: "jane"}
+		cfg.applyGit()
+		if !reflect.DeepEqual(cfg, want) {
+			t.Errorf("applyGit() with git = %+v, want %+v", cfg, want)
+		}
+	}
+
+	// Without git, the settings stay unset
+	t.Setenv("PATH", "")
+	cfg := Config{GitHubUser: "jane"}
+	cfg.applyGit()
+	if want := (Config{GitHubUser: "jane"}); !reflect.DeepEqual(cfg, want) {
+		t.Errorf("applyGit() without git = %+v, want %+v", cfg, want)
+	}
+}
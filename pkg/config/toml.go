@@ -0,0 +1,300 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// bareKey matches the parts of a dotted TOML key that need no quotes
+var bareKey = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// decodeTOML reads the string values of a TOML document by their full
+// dotted key, e.g. "author.name". Settings are strings, other values are
+// rejected
+func decodeTOML(data string) (map[string]string, error) {
+	var doc map[string]any
+	if _, err := toml.Decode(data, &doc); err != nil {
+		return nil, err
+	}
+	values := map[string]string{}
+	if err := flattenTable(values, "", doc); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// flattenTable adds the values of a table and its subtables to values
+func flattenTable(values map[string]string, prefix string, table map[string]any) error {
+	for key, value := range table {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		switch value := value.(type) {
+		case map[string]any:
+			if err := flattenTable(values, key, value); err != nil {
+				return err
+			}
+		case string:
+			values[key] = value
+		default:
+			return fmt.Errorf("invalid value for %s: must be a quoted string", key)
+		}
+	}
+	return nil
+}
+
+// setTOML sets a string value in a TOML document, leaving the rest of the
+// document, comments included, as it is. The line defining the key is
+// replaced; a missing key goes at the end of the table holding it, in place
+// of its commented-out line, or into a new table for profiles. The result is
+// checked to change nothing but the key
+func setTOML(data, key, value string) (string, error) {
+	before, err := decodeTOML(data)
+	if err != nil {
+		return "", err
+	}
+
+	// Every line ends with a newline, including the last one
+	lines := strings.SplitAfter(data, "\n")
+	if last := len(lines) - 1; lines[last] == "" {
+		lines = lines[:last]
+	} else {
+		lines[last] += "\n"
+	}
+
+	// Find the line defining the key, and where each table ends
+	table, firstHeader, commented := "", -1, -1
+	tableEnd := map[string]int{"": -1}
+	commentedTable := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == "":
+			continue
+		case strings.HasPrefix(trimmed, "#"):
+			// The config template lists every setting commented out, followed
+			// by a commented-out profile whose settings are not top-level ones
+			if strings.HasPrefix(trimmed, "# [") {
+				commentedTable = true
+			}
+			if table == "" && !commentedTable && commented < 0 && strings.HasPrefix(trimmed, "# "+key+" =") {
+				commented = i
+			}
+			if table == "" {
+				tableEnd[table] = i
+			}
+			continue
+		case strings.HasPrefix(trimmed, "["):
+			// Lines that do not parse are part of multi-line values
+			name, err := parseHeader(trimmed)
+			if err != nil {
+				continue
+			}
+			if firstHeader < 0 {
+				firstHeader = i
+			}
+			table = name
+			tableEnd[table] = i
+			continue
+		}
+
+		tableEnd[table] = i
+		rawKey, rest, found := cutKey(line)
+		if !found {
+			continue
+		}
+		name, err := parseKey(rawKey)
+		if err != nil {
+			continue
+		}
+		if table != "" {
+			name = table + "." + name
+		}
+		if name == key {
+			lines[i] = rawKey + "= " + quoteString(value) + trailingComment(rest) + "\n"
+			return checkSet(strings.Join(lines, ""), before, key, value)
+		}
+	}
+
+	// Add the key to the longest table holding it
+	holder := ""
+	for name := range tableEnd {
+		if strings.HasPrefix(key, name+".") && len(name) > len(holder) {
+			holder = name
+		}
+	}
+	line := strings.TrimPrefix(strings.TrimPrefix(key, holder), ".") + " = " + quoteString(value) + "\n"
+	switch {
+	case holder != "":
+		lines = insertLine(lines, tableEnd[holder]+1, line)
+	case strings.HasPrefix(key, profilePrefix):
+		// Profiles get a table of their own, e.g. [profiles.work]
+		name, setting, _ := splitProfileKey(key)
+		if len(lines) > 0 {
+			lines = append(lines, "\n")
+		}
+		lines = append(lines, "["+profilePrefix+quoteKeyPart(name)+"]\n", setting+" = "+quoteString(value)+"\n")
+	case commented >= 0:
+		lines[commented] = line
+	case firstHeader >= 0:
+		// Keys outside of a table come before the first table
+		at := tableEnd[""] + 1
+		lines = insertLine(lines, at, line)
+		if at == firstHeader {
+			lines = insertLine(lines, at+1, "\n")
+		}
+	default:
+		lines = append(lines, line)
+	}
+	return checkSet(strings.Join(lines, ""), before, key, value)
+}
+
+// checkSet makes sure that an updated document only changed the value of key
+func checkSet(data string, before map[string]string, key, value string) (string, error) {
+	want := map[string]string{key: value}
+	for k, v := range before {
+		if k != key {
+			want[k] = v
+		}
+	}
+	after, err := decodeTOML(data)
+	if err != nil || !reflect.DeepEqual(after, want) {
+		return "", fmt.Errorf("failed to set %s without rewriting the file, edit it instead", key)
+	}
+	return data, nil
+}
+
+// insertLine inserts a line before the line at index i
+func insertLine(lines []string, i int, line string) []string {
+	return append(lines[:i], append([]string{line}, lines[i:]...)...)
+}
+
+// parseHeader parses a [table] header into its dotted name
+func parseHeader(line string) (string, error) {
+	line = strings.TrimPrefix(strings.TrimPrefix(line, "["), "[")
+	end := unquotedIndex(line, ']')
+	if end < 0 {
+		return "", fmt.Errorf("unterminated table header %q", line)
+	}
+	return parseKey(line[:end])
+}
+
+// cutKey splits a key = value line around the first unquoted '=', keeping
+// the key as written, spaces included
+func cutKey(line string) (rawKey, rest string, found bool) {
+	i := unquotedIndex(line, '=')
+	if i < 0 {
+		return "", "", false
+	}
+	return line[:i], line[i+1:], true
+}
+
+// unquotedIndex returns the index of the first c outside of quotes, or -1
+func unquotedIndex(s string, c byte) int {
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		switch {
+		case quote == '"' && s[i] == '\\':
+			i++
+		case quote != 0:
+			if s[i] == quote {
+				quote = 0
+			}
+		case s[i] == '"' || s[i] == '\'':
+			quote = s[i]
+		case s[i] == c:
+			return i
+		}
+	}
+	return -1
+}
+
+// parseKey parses a dotted key of bare or quoted parts into its dotted name,
+// e.g. author.name or profiles."work".author.name
+func parseKey(raw string) (string, error) {
+	var parts []string
+	rest := strings.TrimSpace(raw)
+	for {
+		i := unquotedIndex(rest, '.')
+		part := rest
+		if i >= 0 {
+			part = rest[:i]
+		}
+		part = strings.TrimSpace(part)
+
+		switch {
+		case strings.HasPrefix(part, `"`):
+			unquoted, err := strconv.Unquote(part)
+			if err != nil {
+				return "", fmt.Errorf("invalid key %q", strings.TrimSpace(raw))
+			}
+			part = unquoted
+		case strings.HasPrefix(part, "'") && len(part) > 1 && strings.HasSuffix(part, "'"):
+			part = part[1 : len(part)-1]
+		case !bareKey.MatchString(part):
+			return "", fmt.Errorf("invalid key %q", strings.TrimSpace(raw))
+		}
+		parts = append(parts, part)
+
+		if i < 0 {
+			return strings.Join(parts, "."), nil
+		}
+		rest = rest[i+1:]
+	}
+}
+
+// trailingComment returns the comment following a string value, with the
+// spaces before it, or nothing
+func trailingComment(rest string) string {
+	value := strings.TrimSpace(rest)
+	if value == "" || value[0] != '"' && value[0] != '\'' {
+		return ""
+	}
+	end := unquotedIndex(value, '#')
+	if end < 0 {
+		return ""
+	}
+	comment := strings.TrimRight(value[end:], "\r\n")
+	return " " + comment
+}
+
+// quoteKeyPart quotes a part of a dotted key unless it is a bare key
+func quoteKeyPart(part string) string {
+	if bareKey.MatchString(part) {
+		return part
+	}
+	return quoteString(part)
+}
+
+// quoteString renders a TOML basic string
+func quoteString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\r':
+			b.WriteString(`\r`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
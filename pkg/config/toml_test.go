@@ -0,0 +1,156 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDecodeTOML(t *testing.T) {
+	data := `# nvim-plugin settings
+top = "level"
+
+[author]
+name = "Jane \"JD\" Doe" # trailing comment
+email = 'jane@example.com'
+
+[defaults]
+output_dir = "~/src/plugins"
+escaped = "tab\thereé"
+nested.key = "dotted"
+"quoted key" = "quoted"
+
+[profiles.work]
+author = { email = "jane.doe@corp.example" }
+`
+	values, err := decodeTOML(data)
+	if err != nil {
+		t.Fatalf("decodeTOML failed: %v", err)
+	}
+
+	want := map[string]string{
+		"top":                        "level",
+		"author.name":                `Jane "JD" Doe`,
+		"author.email":               "jane@example.com",
+		"defaults.output_dir":        "~/src/plugins",
+		"defaults.escaped":           "tab\thereé",
+		"defaults.nested.key":        "dotted",
+		"defaults.quoted key":        "quoted",
+		"profiles.work.author.email": "jane.doe@corp.example",
+	}
+	if !reflect.DeepEqual(values, want) {
+		t.Errorf("decodeTOML() = %v, want %v", values, want)
+	}
+}
+
+func TestDecodeTOMLErrors(t *testing.T) {
+	invalid := []string{
+		"name",
+		"name = ",
+		`name = "unterminated`,
+		`name = "bad \q escape"`,
+		`name = "value" extra`,
+		"name = bare",
+		"[author",
+		"bad key = 1",
+		"a = \"1\"\na = \"2\"",
+		// Settings are strings
+		"enabled = true",
+		"count = 1_000",
+		`names = ["a", "b"]`,
+	}
+
+	for _, data := range invalid {
+		if _, err := decodeTOML(data); err == nil {
+			t.Errorf("decodeTOML(%q) should have returned an error", data)
+		}
+	}
+}
+
+func TestSetTOML(t *testing.T) {
+	tests := []struct {
+		name  string
+		data  string
+		key   string
+		value string
+		want  string
+	}{
+		{
+			name:  "empty file",
+			key:   "author.name",
+			value: "Jane",
+			want:  "author.name = \"Jane\"\n",
+		},
+		{
+			name:  "commented-out setting",
+			data:  "# Name of the plugin author\n# author.name = \"\"\n\n# Email\n# author.email = \"\"\n",
+			key:   "author.name",
+			value: "Jane",
+			want:  "# Name of the plugin author\nauthor.name = \"Jane\"\n\n# Email\n# author.email = \"\"\n",
+		},
+		{
+			name:  "commented-out setting and profile",
+			data:  "# author.email = \"\"\n\n# [profiles.work]\n# author.email = \"\"\n",
+			key:   "author.email",
+			value: "jane@example.com",
+			want:  "author.email = \"jane@example.com\"\n\n# [profiles.work]\n# author.email = \"\"\n",
+		},
+		{
+			name:  "existing key",
+			data:  "# Author\n[author]\nname = \"Jane\" # full name\nemail = \"jane@example.com\"",
+			key:   "author.name",
+			value: `Jane "JD" Doe`,
+			want:  "# Author\n[author]\nname = \"Jane \\\"JD\\\" Doe\" # full name\nemail = \"jane@example.com\"\n",
+		},
+		{
+			name:  "quoted key",
+			data:  "\"author\".'name' = \"Jane\"\n",
+			key:   "author.name",
+			value: "John",
+			want:  "\"author\".'name' = \"John\"\n",
+		},
+		{
+			name:  "missing key of a table",
+			data:  "[author]\nname = \"Jane\"\n\n# Defaults\n[defaults]\nflavor = \"vim\"\n",
+			key:   "author.email",
+			value: "jane@example.com",
+			want:  "[author]\nname = \"Jane\"\nemail = \"jane@example.com\"\n\n# Defaults\n[defaults]\nflavor = \"vim\"\n",
+		},
+		{
+			name:  "missing key outside of the tables",
+			data:  "[defaults]\nflavor = \"vim\"\n",
+			key:   "github.user",
+			value: "jane",
+			want:  "github.user = \"jane\"\n\n[defaults]\nflavor = \"vim\"\n",
+		},
+		{
+			name:  "new profile",
+			data:  "author.name = \"Jane\"\n",
+			key:   "profiles.work.defaults.host",
+			value: "git.corp.example",
+			want:  "author.name = \"Jane\"\n\n[profiles.work]\ndefaults.host = \"git.corp.example\"\n",
+		},
+		{
+			name:  "existing profile",
+			data:  "[profiles.work]\nauthor.email = \"jane@corp.example\"\n",
+			key:   "profiles.work.defaults.host",
+			value: "git.corp.example",
+			want:  "[profiles.work]\nauthor.email = \"jane@corp.example\"\ndefaults.host = \"git.corp.example\"\n",
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := setTOML(tt.data, tt.key, tt.value)
+		if err != nil {
+			t.Errorf("%s: setTOML failed: %v", tt.name, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s: setTOML() = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	// Values spanning lines cannot be replaced line by line
+	if _, err := setTOML("[author]\nname = \"\"\"Jane\nDoe\"\"\"\n", "author.name", "Jane"); err == nil {
+		t.Errorf("setTOML should refuse to replace a multi-line value")
+	}
+}
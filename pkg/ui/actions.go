@@ -0,0 +1,148 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// actionName matches names usable in a <Plug> mapping and, with hyphens
+// replaced, as a Lua or Vim script function name
+var actionName = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*$`)
+
+// reservedActions are the members the entry points define next to the
+// action functions: M.setup, M.run and M.options of the Lua module, and the
+// #run() and #options() autoload functions of the Vim script flavors
+var reservedActions = []string{"setup", "run", "options"}
+
+// defaultKeymapsOption guards the default keymaps of the actions
+// It is added to the option schema when an action declares default keys
+var defaultKeymapsOption = Option{
+	Name:        "default_keymaps",
+	Type:        "boolean",
+	Default:     "true",
+	Description: "Map the default keys of the actions, set to false to map them yourself",
+}
+
+// Action is an operation of the generated plugin exposed as a
+// <Plug>(name-action) mapping in the plugin entry point. When Keys is set,
+// setup() maps them to the <Plug> mapping unless default keymaps are disabled.
+type Action struct {
+	Name        string // Suffix of the <Plug> mapping, e.g. "toggle"
+	Keys        string // Default keys in normal mode, none when empty
+	Description string // One-line description used in the mapping and docs
+}
+
+// ParseAction parses an action declaration of the form
+// name[:keys[:description]]
+// The description may itself contain colons
+func ParseAction(spec string) (Action, error) {
+	parts := strings.SplitN(spec, ":", 3)
+
+	action := Action{Name: strings.TrimSpace(parts[0])}
+	if len(parts) > 1 {
+		action.Keys = strings.TrimSpace(parts[1])
+	}
+	if len(parts) > 2 {
+		action.Description = strings.TrimSpace(parts[2])
+	}
+	if action.Description == "" {
+		action.Description = "TODO: describe " + action.Name
+	}
+
+	if err := action.Validate(); err != nil {
+		return Action{}, err
+	}
+	return action, nil
+}
+
+// Validate checks that the action can be rendered as a mapping and a function
+func (a Action) Validate() error {
+	if !actionName.MatchString(a.Name) {
+		return fmt.Errorf("invalid action name %q: must start with a letter and contain only letters, digits, hyphens and underscores", a.Name)
+	}
+	if isLuaKeyword(a.FuncName()) {
+		return fmt.Errorf("invalid action name %q: %s is a Lua keyword", a.Name, a.FuncName())
+	}
+	for _, reserved := range reservedActions {
+		if a.FuncName() == reserved {
+			return fmt.Errorf("invalid action name %q: %s is already defined by the plugin", a.Name, reserved)
+		}
+	}
+	if strings.ContainsAny(a.Keys, " \t") {
+		return fmt.Errorf("invalid keys %q for action %s: use <Space> instead of spaces", a.Keys, a.Name)
+	}
+	return nil
+}
+
+// FuncName returns the name of the Lua or Vim script function running the action
+func (a Action) FuncName() string {
+	return strings.ReplaceAll(a.Name, "-", "_")
+}
+
+// LuaKeys renders the default keys as a Lua string literal
+func (a Action) LuaKeys() string {
+	return luaString(a.Keys)
+}
+
+// LuaDescription renders the description as a Lua string literal
+func (a Action) LuaDescription() string {
+	return luaString(a.Description)
+}
+
+// Plug returns the <Plug> mapping of an action of the plugin
+func (d TemplateData) Plug(action Action) string {
+	return "<Plug>(" + d.ModuleName + "-" + action.Name + ")"
+}
+
+// DefaultKeymaps returns the actions mapped to default keys
+func (d TemplateData) DefaultKeymaps() []Action {
+	var actions []Action
+	for _, action := range d.Actions {
+		if action.Keys != "" {
+			actions = append(actions, action)
+		}
+	}
+	return actions
+}
+
+// validateActions checks the declared actions and that the flavor supports them
+func validateActions(flavor Flavor, actions []Action) error {
+	if len(actions) > 0 && !flavor.UsesActions {
+		return fmt.Errorf("the %s flavor does not support actions", flavor.Name)
+	}
+
+	seen := map[string]bool{}
+	for _, action := range actions {
+		if err := action.Validate(); err != nil {
+			return err
+		}
+		if seen[action.FuncName()] {
+			return fmt.Errorf("duplicate action %q", action.Name)
+		}
+		seen[action.FuncName()] = true
+	}
+	return nil
+}
+
+// withDefaultKeymapsOption adds the option guarding the default keymaps when
+// an action declares default keys and the option is not declared yet
+func withDefaultKeymapsOption(options []Option, actions []Action) []Option {
+	hasKeys := false
+	for _, action := range actions {
+		if action.Keys != "" {
+			hasKeys = true
+			break
+		}
+	}
+	if !hasKeys {
+		return options
+	}
+
+	for _, option := range options {
+		if option.Name == defaultKeymapsOption.Name {
+			return options
+		}
+	}
+	return append(append([]Option(nil), options...), defaultKeymapsOption)
+}
@@ -0,0 +1,106 @@
+package ui
+
+import "testing"
+
+func TestParseAction(t *testing.T) {
+	tests := []struct {
+		spec     string
+		expected Action
+	}{
+		{
+			"toggle:<leader>tt:Toggle the panel",
+			Action{Name: "toggle", Keys: "<leader>tt", Description: "Toggle the panel"},
+		},
+		{
+			"open-file",
+			Action{Name: "open-file", Description: "TODO: describe open-file"},
+		},
+		{
+			"jump::Jump to: the next item",
+			Action{Name: "jump", Description: "Jump to: the next item"},
+		},
+	}
+
+	for _, test := range tests {
+		action, err := ParseAction(test.spec)
+		if err != nil {
+			t.Errorf("ParseAction(%q) failed: %v", test.spec, err)
+			continue
+		}
+		if action != test.expected {
+			t.Errorf("ParseAction(%q) = %+v, expected %+v", test.spec, action, test.expected)
+		}
+	}
+}
+
+func TestParseActionErrors(t *testing.T) {
+	invalid := []string{
+		"",
+		"1toggle",
+		"open file",
+		"toggle:<leader> t",
+		"repeat:<leader>r",
+		"setup",
+		"run:<leader>r",
+		"options",
+	}
+
+	for _, spec := range invalid {
+		if _, err := ParseAction(spec); err == nil {
+			t.Errorf("ParseAction(%q) should have returned an error", spec)
+		}
+	}
+}
+
+func TestValidateActions(t *testing.T) {
+	lua, _ := LookupFlavor("lua")
+	colorscheme, _ := LookupFlavor("colorscheme")
+	actions := []Action{{Name: "open-file"}, {Name: "toggle"}}
+
+	if err := validateActions(lua, actions); err != nil {
+		t.Errorf("validateActions failed: %v", err)
+	}
+	if err := validateActions(colorscheme, actions); err == nil {
+		t.Errorf("The colorscheme flavor should not support actions")
+	}
+	if err := validateActions(lua, append(actions, Action{Name: "open_file"})); err == nil {
+		t.Errorf("Actions with the same function name should be rejected")
+	}
+}
+
+func TestWithDefaultKeymapsOption(t *testing.T) {
+	options := []Option{{Name: "enabled", Type: "boolean"}}
+
+	// Actions without keys need no option
+	result := withDefaultKeymapsOption(options, []Action{{Name: "toggle"}})
+	if len(result) != 1 {
+		t.Errorf("Expected no added option, got %+v", result)
+	}
+
+	// Actions with keys add the option once
+	withKeys := []Action{{Name: "toggle", Keys: "<leader>tt"}}
+	result = withDefaultKeymapsOption(options, withKeys)
+	if len(result) != 2 || result[1] != defaultKeymapsOption {
+		t.Fatalf("Expected the default_keymaps option to be added, got %+v", result)
+	}
+	if len(options) != 1 {
+		t.Errorf("The declared options should not be modified")
+	}
+	if again := withDefaultKeymapsOption(result, withKeys); len(again) != 2 {
+		t.Errorf("The default_keymaps option should not be added twice, got %+v", again)
+	}
+}
+
+func TestTemplateDataPlug(t *testing.T) {
+	data := TemplateData{
+		ModuleName: "my-plugin",
+		Actions:    []Action{{Name: "toggle", Keys: "<leader>tt"}, {Name: "open"}},
+	}
+
+	if plug := data.Plug(data.Actions[0]); plug != "<Plug>(my-plugin-toggle)" {
+		t.Errorf("Plug() = %q, expected <Plug>(my-plugin-toggle)", plug)
+	}
+	if keymaps := data.DefaultKeymaps(); len(keymaps) != 1 || keymaps[0].Name != "toggle" {
+		t.Errorf("DefaultKeymaps() = %+v, expected only the toggle action", keymaps)
+	}
+}
@@ -0,0 +1,114 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// luaKeywords are the reserved words of Lua, which cannot be used as
+// identifiers or as keys of table constructors in the generated code
+var luaKeywords = []string{
+	"and", "break", "do", "else", "elseif", "end", "false", "for", "function", "goto",
+	"if", "in", "local", "nil", "not", "or", "repeat", "return", "then", "true", "until", "while",
+}
+
+// commonModules are Lua modules that are already loaded or widely installed,
+// the standard library, Neovim itself and popular plugins. require() returns
+// the first module found, so a plugin reusing one of these names may load the
+// wrong code
+var commonModules = []string{
+	// Lua, LuaJIT and Neovim
+	"bit", "coroutine", "debug", "ffi", "io", "jit", "math", "os", "package", "string", "table", "utf8", "vim",
+	// Popular plugins
+	"blink", "cmp", "conform", "dap", "fzf-lua", "gitsigns", "lazy", "lspconfig", "lualine", "luasnip",
+	"mini", "neo-tree", "noice", "notify", "null-ls", "nvim-tree", "nvim-treesitter", "oil", "plenary",
+	"snacks", "telescope", "trouble", "which-key",
+}
+
+// isLuaKeyword reports whether a name is a reserved word of Lua
+func isLuaKeyword(name string) bool {
+	return containsString(luaKeywords, name)
+}
+
+// ModuleWarnings lists the reasons why require() of a Lua module may not load
+// the generated plugin: a common module or a plugin of the Neovim data
+// directory providing a module of the same name. They are warnings, the module name stays valid
+// Only the plugins of the Neovim data directory are checked, not the whole
+// runtimepath, so no warning does not rule out a collision
+func ModuleWarnings(module string) []string {
+	return moduleWarnings(module, nvimDataDir())
+}
+
+// moduleWarnings lists the collisions of a Lua module with the common modules
+// and the plugins installed in a Neovim data directory
+func moduleWarnings(module, dataDir string) []string {
+	var warnings []string
+	if containsString(commonModules, module) {
+		warnings = append(warnings, fmt.Sprintf("the Lua module %q is a common module, require(%q) may load it instead of the plugin", module, module))
+	}
+	for _, plugin := range installedPlugins(dataDir) {
+		if providesModule(filepath.Join(plugin, "lua"), module) {
+			warnings = append(warnings, fmt.Sprintf("the Lua module %q is also provided by the plugin %s of the Neovim data directory", module, plugin))
+		}
+	}
+	if dataDir != "" && providesModule(filepath.Join(dataDir, rocksLuaDir), module) {
+		warnings = append(warnings, fmt.Sprintf("the Lua module %q is also provided by a rock installed with rocks.nvim in the Neovim data directory, %s", module, filepath.Join(dataDir, "rocks")))
+	}
+	return warnings
+}
+
+// nvimDataDir returns the data directory of Neovim, where plugin managers
+// install plugins: $XDG_DATA_HOME/nvim, defaulting to ~/.local/share/nvim
+func nvimDataDir() string {
+	if dataHome := os.Getenv("XDG_DATA_HOME"); dataHome != "" {
+		return filepath.Join(dataHome, "nvim")
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".local", "share", "nvim")
+}
+
+// rocksLuaDir is the directory of the data directory where rocks.nvim
+// installs the Lua modules of its rocks
+var rocksLuaDir = filepath.Join("rocks", "share", "lua", "5.1")
+
+// installedPlugins lists the plugin directories installed in a Neovim data
+// directory: packages, used by vim.pack, packer.nvim and mini.deps, and the
+// directories of lazy.nvim and vim-plug. Plugins loaded from elsewhere, such
+// as a custom packpath, a plugged/ directory outside of the data directory
+// or a local checkout added to the runtimepath, are not found
+func installedPlugins(dataDir string) []string {
+	if dataDir == "" {
+		return nil
+	}
+
+	var plugins []string
+	for _, pattern := range []string{
+		filepath.Join(dataDir, "site", "pack", "*", "start", "*"),
+		filepath.Join(dataDir, "site", "pack", "*", "opt", "*"),
+		filepath.Join(dataDir, "lazy", "*"),
+		filepath.Join(dataDir, "plugged", "*"),
+	} {
+		// Glob only fails on malformed patterns
+		matches, _ := filepath.Glob(pattern)
+		plugins = append(plugins, matches...)
+	}
+	return plugins
+}
+
+// providesModule reports whether a Lua directory provides a module, either
+// as <module>/ or <module>.lua
+func providesModule(luaDir, module string) bool {
+	for _, path := range []string{
+		filepath.Join(luaDir, module),
+		filepath.Join(luaDir, module+".lua"),
+	} {
+		if _, err := os.Stat(path); err == nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestModuleWarnings(t *testing.T) {
+	dataDir := t.TempDir()
+	for _, dir := range []string{
+		filepath.Join(dataDir, "site", "pack", "plugins", "start", "foo.nvim", "lua", "foo"),
+		filepath.Join(dataDir, "site", "pack", "plugins", "opt", "bar.nvim", "lua"),
+		filepath.Join(dataDir, "lazy", "baz.nvim", "lua", "baz"),
+		filepath.Join(dataDir, "plugged", "qux.vim", "lua", "qux"),
+		filepath.Join(dataDir, rocksLuaDir, "rock"),
+	} {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dataDir, "site", "pack", "plugins", "opt", "bar.nvim", "lua", "bar.lua"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		module string
+		want   []string
+	}{
+		{module: "unique", want: nil},
+		{module: "telescope", want: []string{"common module"}},
+		{module: "foo", want: []string{"start/foo.nvim of the Neovim data directory"}},
+		{module: "bar", want: []string{"opt/bar.nvim"}},
+		{module: "baz", want: []string{"lazy/baz.nvim"}},
+		{module: "qux", want: []string{"plugged/qux.vim"}},
+		{module: "rock", want: []string{"rocks.nvim in the Neovim data directory"}},
+	}
+
+	for _, tt := range tests {
+		warnings := moduleWarnings(tt.module, dataDir)
+		if len(warnings) != len(tt.want) {
+			t.Errorf("moduleWarnings(%q) = %q, want %d warnings", tt.module, warnings, len(tt.want))
+			continue
+		}
+		for i, want := range tt.want {
+			if !strings.Contains(warnings[i], want) {
+				t.Errorf("moduleWarnings(%q)[%d] = %q, should mention %q", tt.module, i, warnings[i], want)
+			}
+		}
+	}
+}
+
+func TestNvimDataDir(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", "/data")
+	if got, want := nvimDataDir(), filepath.Join("/data", "nvim"); got != want {
+		t.Errorf("nvimDataDir() = %q, want %q", got, want)
+	}
+
+	t.Setenv("XDG_DATA_HOME", "")
+	t.Setenv("HOME", "/home/me")
+	if got, want := nvimDataDir(), filepath.Join("/home/me", ".local", "share", "nvim"); got != want {
+		t.Errorf("nvimDataDir() = %q, want %q", got, want)
+	}
+}
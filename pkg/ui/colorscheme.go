@@ -0,0 +1,145 @@
+package ui
+
+// HighlightGroup is a highlight group defined by the colorscheme flavor
+type HighlightGroup struct {
+	Name string // Highlight group name, e.g. "Normal" or "@variable"
+	Spec string // Lua table passed to nvim_set_hl, using the palette `p` and options `config`
+}
+
+// HighlightCategory groups related highlight groups in groups.lua and the vimdoc
+type HighlightCategory struct {
+	Name   string
+	Groups []HighlightGroup
+}
+
+// highlightCategories is the single source for the groups defined by a
+// generated colorscheme and for the list of groups in its documentation
+var highlightCategories = []HighlightCategory{
+	{
+		Name: "Editor",
+		Groups: []HighlightGroup{
+			{"Normal", "{ fg = p.fg, bg = p.bg }"},
+			{"NormalFloat", "{ fg = p.fg, bg = p.bg_alt }"},
+			{"FloatBorder", "{ fg = p.comment, bg = p.bg_alt }"},
+			{"Cursor", "{ fg = p.bg, bg = p.fg }"},
+			{"CursorLine", "{ bg = p.bg_highlight }"},
+			{"CursorLineNr", "{ fg = p.yellow, bold = true }"},
+			{"LineNr", "{ fg = p.comment }"},
+			{"SignColumn", "{ bg = p.bg }"},
+			{"ColorColumn", "{ bg = p.bg_alt }"},
+			{"Visual", "{ bg = p.selection }"},
+			{"Search", "{ fg = p.bg, bg = p.yellow }"},
+			{"IncSearch", "{ fg = p.bg, bg = p.orange }"},
+			{"MatchParen", "{ fg = p.orange, bold = true }"},
+			{"Pmenu", "{ fg = p.fg, bg = p.bg_alt }"},
+			{"PmenuSel", "{ bg = p.selection }"},
+			{"StatusLine", "{ fg = p.fg, bg = p.bg_alt }"},
+			{"StatusLineNC", "{ fg = p.comment, bg = p.bg_alt }"},
+			{"WinSeparator", "{ fg = p.bg_highlight }"},
+			{"Folded", "{ fg = p.comment, bg = p.bg_alt }"},
+			{"NonText", "{ fg = p.bg_highlight }"},
+			{"Title", "{ fg = p.blue, bold = true }"},
+		},
+	},
+	{
+		Name: "Syntax",
+		Groups: []HighlightGroup{
+			{"Comment", "{ fg = p.comment, italic = config.italic_comments }"},
+			{"Constant", "{ fg = p.orange }"},
+			{"String", "{ fg = p.green }"},
+			{"Character", "{ fg = p.green }"},
+			{"Number", "{ fg = p.orange }"},
+			{"Boolean", "{ fg = p.orange }"},
+			{"Identifier", "{ fg = p.fg }"},
+			{"Function", "{ fg = p.blue }"},
+			{"Statement", "{ fg = p.purple }"},
+			{"Keyword", "{ fg = p.purple }"},
+			{"Operator", "{ fg = p.cyan }"},
+			{"Type", "{ fg = p.yellow }"},
+			{"PreProc", "{ fg = p.cyan }"},
+			{"Special", "{ fg = p.cyan }"},
+			{"Delimiter", "{ fg = p.fg_alt }"},
+			{"Todo", "{ fg = p.bg, bg = p.yellow, bold = true }"},
+			{"Error", "{ fg = p.red }"},
+		},
+	},
+	{
+		Name: "Diff",
+		Groups: []HighlightGroup{
+			{"DiffAdd", "{ fg = p.green, bg = p.bg_alt }"},
+			{"DiffChange", "{ fg = p.yellow, bg = p.bg_alt }"},
+			{"DiffDelete", "{ fg = p.red, bg = p.bg_alt }"},
+			{"DiffText", "{ fg = p.bg, bg = p.yellow }"},
+		},
+	},
+	{
+		Name: "Diagnostics",
+		Groups: []HighlightGroup{
+			{"DiagnosticError", "{ fg = p.red }"},
+			{"DiagnosticWarn", "{ fg = p.yellow }"},
+			{"DiagnosticInfo", "{ fg = p.blue }"},
+			{"DiagnosticHint", "{ fg = p.cyan }"},
+			{"DiagnosticUnderlineError", "{ undercurl = true, sp = p.red }"},
+			{"DiagnosticUnderlineWarn", "{ undercurl = true, sp = p.yellow }"},
+			{"DiagnosticUnderlineInfo", "{ undercurl = true, sp = p.blue }"},
+			{"DiagnosticUnderlineHint", "{ undercurl = true, sp = p.cyan }"},
+		},
+	},
+	{
+		Name: "Treesitter",
+		Groups: []HighlightGroup{
+			{"@variable", "{ fg = p.fg }"},
+			{"@variable.builtin", "{ fg = p.red }"},
+			{"@variable.parameter", "{ fg = p.fg_alt, italic = true }"},
+			{"@variable.member", "{ fg = p.cyan }"},
+			{"@constant", `{ link = "Constant" }`},
+			{"@constant.builtin", "{ fg = p.orange, bold = true }"},
+			{"@module", "{ fg = p.yellow }"},
+			{"@string", `{ link = "String" }`},
+			{"@string.escape", "{ fg = p.cyan }"},
+			{"@character", `{ link = "Character" }`},
+			{"@number", `{ link = "Number" }`},
+			{"@boolean", `{ link = "Boolean" }`},
+			{"@function", `{ link = "Function" }`},
+			{"@function.builtin", "{ fg = p.blue, italic = true }"},
+			{"@function.method", `{ link = "Function" }`},
+			{"@constructor", "{ fg = p.yellow }"},
+			{"@keyword", `{ link = "Keyword" }`},
+			{"@keyword.return", "{ fg = p.purple, italic = true }"},
+			{"@operator", `{ link = "Operator" }`},
+			{"@type", `{ link = "Type" }`},
+			{"@type.builtin", "{ fg = p.yellow, italic = true }"},
+			{"@property", "{ fg = p.cyan }"},
+			{"@attribute", "{ fg = p.cyan }"},
+			{"@punctuation.delimiter", `{ link = "Delimiter" }`},
+			{"@punctuation.bracket", "{ fg = p.fg_alt }"},
+			{"@comment", `{ link = "Comment" }`},
+			{"@tag", "{ fg = p.red }"},
+			{"@markup.heading", `{ link = "Title" }`},
+			{"@markup.link", "{ fg = p.blue, underline = true }"},
+		},
+	},
+	{
+		Name: "LSP semantic tokens",
+		Groups: []HighlightGroup{
+			{"@lsp.type.class", `{ link = "@type" }`},
+			{"@lsp.type.enum", `{ link = "@type" }`},
+			{"@lsp.type.interface", `{ link = "@type" }`},
+			{"@lsp.type.type", `{ link = "@type" }`},
+			{"@lsp.type.function", `{ link = "@function" }`},
+			{"@lsp.type.method", `{ link = "@function.method" }`},
+			{"@lsp.type.namespace", `{ link = "@module" }`},
+			{"@lsp.type.parameter", `{ link = "@variable.parameter" }`},
+			{"@lsp.type.property", `{ link = "@property" }`},
+			{"@lsp.type.variable", `{ link = "@variable" }`},
+			{"@lsp.type.keyword", `{ link = "@keyword" }`},
+			{"@lsp.mod.deprecated", "{ strikethrough = true }"},
+			{"@lsp.typemod.function.defaultLibrary", `{ link = "@function.builtin" }`},
+		},
+	},
+}
+
+// HighlightGroups returns the highlight groups defined by the colorscheme flavor
+func (d TemplateData) HighlightGroups() []HighlightCategory {
+	return highlightCategories
+}
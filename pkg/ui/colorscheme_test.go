@@ -0,0 +1,44 @@
+package ui
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestHighlightGroups(t *testing.T) {
+	palette, err := templateFS.ReadFile("templates/colorscheme/lua/plugin_name/palette.lua.tmpl")
+	if err != nil {
+		t.Fatalf("Failed to read palette template: %v", err)
+	}
+
+	// Collect the colors defined by the dark palette
+	defined := map[string]bool{}
+	for _, match := range regexp.MustCompile(`(?m)^    (\w+) = "#`).FindAllStringSubmatch(string(palette), -1) {
+		defined[match[1]] = true
+	}
+
+	seen := map[string]bool{}
+	paletteRef := regexp.MustCompile(`p\.(\w+)`)
+	for _, category := range highlightCategories {
+		for _, group := range category.Groups {
+			if seen[group.Name] {
+				t.Errorf("Highlight group %s is defined twice", group.Name)
+			}
+			seen[group.Name] = true
+
+			// Every color used by a group must exist in the palette
+			for _, match := range paletteRef.FindAllStringSubmatch(group.Spec, -1) {
+				if !defined[match[1]] {
+					t.Errorf("Highlight group %s uses undefined palette color %q", group.Name, match[1])
+				}
+			}
+		}
+	}
+
+	// Treesitter captures and LSP semantic tokens are expected by modern colorschemes
+	for _, name := range []string{"Normal", "@variable", "@lsp.type.function", "DiagnosticError"} {
+		if !seen[name] {
+			t.Errorf("Expected highlight group %s to be defined", name)
+		}
+	}
+}
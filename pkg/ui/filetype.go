@@ -0,0 +1,48 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// filetypePattern matches names Neovim accepts as a filetype and that are safe
+// to use in file names such as ftplugin/{filetype}.lua
+var filetypePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// extensionPattern matches a file extension without its leading dot
+var extensionPattern = regexp.MustCompile(`^[A-Za-z0-9_+-]+$`)
+
+// ValidateFiletype checks that a filetype name can be used by the filetype flavor
+func ValidateFiletype(filetype string) error {
+	if !filetypePattern.MatchString(filetype) {
+		return fmt.Errorf("invalid filetype %q: use lowercase letters, digits and underscores, starting with a letter", filetype)
+	}
+	return nil
+}
+
+// ParseExtensions parses a comma or space separated list of file extensions
+// Leading dots are optional, so ".foo, bar" yields ["foo", "bar"]
+func ParseExtensions(list string) ([]string, error) {
+	fields := strings.FieldsFunc(list, func(r rune) bool {
+		return r == ',' || r == ' '
+	})
+
+	var extensions []string
+	seen := map[string]bool{}
+	for _, field := range fields {
+		extension := strings.TrimPrefix(field, ".")
+		if !extensionPattern.MatchString(extension) {
+			return nil, fmt.Errorf("invalid file extension %q", field)
+		}
+		if !seen[extension] {
+			seen[extension] = true
+			extensions = append(extensions, extension)
+		}
+	}
+
+	if len(extensions) == 0 {
+		return nil, fmt.Errorf("at least one file extension is required")
+	}
+	return extensions, nil
+}
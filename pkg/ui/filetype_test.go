@@ -0,0 +1,53 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidateFiletype(t *testing.T) {
+	valid := []string{"lua", "my_lang", "x86asm"}
+	for _, filetype := range valid {
+		if err := ValidateFiletype(filetype); err != nil {
+			t.Errorf("ValidateFiletype(%q) returned error: %v", filetype, err)
+		}
+	}
+
+	invalid := []string{"", "MyLang", "my-lang", "1lang", "../lang", "my lang"}
+	for _, filetype := range invalid {
+		if err := ValidateFiletype(filetype); err == nil {
+			t.Errorf("ValidateFiletype(%q) should have returned an error", filetype)
+		}
+	}
+}
+
+func TestParseExtensions(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{"foo", []string{"foo"}},
+		{".foo, bar", []string{"foo", "bar"}},
+		{"foo bar,baz", []string{"foo", "bar", "baz"}},
+		{"foo, .foo", []string{"foo"}},
+		{"c++", []string{"c++"}},
+	}
+
+	for _, test := range tests {
+		result, err := ParseExtensions(test.input)
+		if err != nil {
+			t.Errorf("ParseExtensions(%q) returned error: %v", test.input, err)
+			continue
+		}
+		if !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("ParseExtensions(%q) = %q, expected %q", test.input, result, test.expected)
+		}
+	}
+
+	invalid := []string{"", " , ", "foo/bar", "*.foo"}
+	for _, input := range invalid {
+		if _, err := ParseExtensions(input); err == nil {
+			t.Errorf("ParseExtensions(%q) should have returned an error", input)
+		}
+	}
+}
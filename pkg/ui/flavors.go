@@ -0,0 +1,374 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// templateFile maps a template to the file it generates
+// The output path is relative to the plugin directory and is itself
+// rendered as a template, e.g. "lua/{{.ModuleName}}/init.lua"
+type templateFile struct {
+	outputPath string
+	tmplPath   string
+}
+
+// Flavor is a template set for a particular kind of plugin
+type Flavor struct {
+	Name         string   // Identifier used by the wizard and the --flavor flag
+	Description  string   // One-line summary shown in the wizard
+	Options      []Option // Default option schema, extended by the options the user declares
+	UsesFiletype bool     // Whether the flavor needs a filetype and its file extensions
+	OptionTypes  []string // Option types the flavor can render, every type when empty
+	UsesActions  bool     // Whether the entry point exposes actions as <Plug> mappings
+	Commands     []string // Suffixes of the user commands appended to the command name, "" for the command itself
+	Events       []string // Events lazy.nvim loads the plugin on
+	files        []templateFile
+	partials     string // Template overriding the blocks of the shared README and vimdoc
+}
+
+// defaultFlavor is used when no flavor is selected
+const defaultFlavor = "lua"
+
+// gitignoreFile ignores the generated help tags and local test dependencies
+// go:embed skips files starting with a dot, hence no ".gitignore" template
+var gitignoreFile = templateFile{outputPath: ".gitignore", tmplPath: "templates/gitignore.tmpl"}
+
+// docFiles are generated for every flavor with a Lua module
+var docFiles = []templateFile{
+	{outputPath: "README.md", tmplPath: "templates/README.md.tmpl"},
+	{outputPath: "doc/{{.ModuleName}}.txt", tmplPath: "templates/doc/plugin.txt.tmpl"},
+	{outputPath: ".stylua.toml", tmplPath: "templates/stylua.toml.tmpl"},
+	{outputPath: ".luarc.json", tmplPath: "templates/luarc.json.tmpl"},
+	gitignoreFile,
+}
+
+// moduleFiles hold the option defaults, validation, type annotations and
+// health check shared by every flavor with a Lua module
+var moduleFiles = []templateFile{
+	{outputPath: "lua/{{.ModuleName}}/config.lua", tmplPath: "templates/lua/plugin_name/config.lua.tmpl"},
+	{outputPath: "lua/{{.ModuleName}}/types.lua", tmplPath: "templates/lua/plugin_name/types.lua.tmpl"},
+	{outputPath: "lua/{{.ModuleName}}/health.lua", tmplPath: "templates/lua/plugin_name/health.lua.tmpl"},
+}
+
+// autocmdsFile holds the autocommands of the plugin, generated on request
+var autocmdsFile = templateFile{outputPath: "lua/{{.ModuleName}}/autocmds.lua", tmplPath: "templates/lua/plugin_name/autocmds.lua.tmpl"}
+
+// autocmdsEvent is the event the autocmds module reacts to, loading the plugin with lazy.nvim
+const autocmdsEvent = "BufEnter"
+
+// testFiles scaffold the plenary/busted tests of every flavor with a Lua module
+// A flavor adds its own cases by overriding the "spec-cases" block
+var testFiles = []templateFile{
+	{outputPath: "tests/minimal_init.lua", tmplPath: "templates/tests/minimal_init.lua.tmpl"},
+	{outputPath: "tests/{{.ModuleName}}_spec.lua", tmplPath: "templates/tests/plugin_spec.lua.tmpl"},
+}
+
+// flavors lists every available template set, the default one first
+var flavors = []Flavor{
+	{
+		Name:        "lua",
+		Description: "General purpose Lua plugin with a user command",
+		UsesActions: true,
+		Commands:    []string{""},
+		files: concatFiles(
+			[]templateFile{
+				{outputPath: "lua/{{.ModuleName}}/init.lua", tmplPath: "templates/lua/plugin_name/init.lua.tmpl"},
+				{outputPath: "plugin/{{.ModuleName}}.lua", tmplPath: "templates/plugin/plugin.lua.tmpl"},
+			},
+			moduleFiles,
+			testFiles,
+			docFiles,
+		),
+	},
+	{
+		Name:        "colorscheme",
+		Description: "Colorscheme with a palette, highlight groups and light/dark variants",
+		Options: []Option{
+			{Name: "variant", Type: "string", Default: "auto", Description: `Palette to use: "dark", "light" or "auto" to follow 'background'`},
+			{Name: "transparent", Type: "boolean", Default: "false", Description: "Do not set the background color"},
+			{Name: "italic_comments", Type: "boolean", Default: "true", Description: "Render comments in italics"},
+		},
+		files: concatFiles(
+			[]templateFile{
+				{outputPath: "colors/{{.ModuleName}}.lua", tmplPath: "templates/colorscheme/colors/colorscheme.lua.tmpl"},
+				{outputPath: "colors/{{.ModuleName}}-dark.lua", tmplPath: "templates/colorscheme/colors/colorscheme-dark.lua.tmpl"},
+				{outputPath: "colors/{{.ModuleName}}-light.lua", tmplPath: "templates/colorscheme/colors/colorscheme-light.lua.tmpl"},
+				{outputPath: "lua/{{.ModuleName}}/init.lua", tmplPath: "templates/colorscheme/lua/plugin_name/init.lua.tmpl"},
+				{outputPath: "lua/{{.ModuleName}}/palette.lua", tmplPath: "templates/colorscheme/lua/plugin_name/palette.lua.tmpl"},
+				{outputPath: "lua/{{.ModuleName}}/groups.lua", tmplPath: "templates/colorscheme/lua/plugin_name/groups.lua.tmpl"},
+			},
+			moduleFiles,
+			testFiles,
+			docFiles,
+		),
+		partials: "templates/colorscheme/docs.tmpl",
+	},
+	{
+		Name:        "telescope",
+		Description: "telescope.nvim extension with a picker",
+		Options: []Option{
+			{Name: "previewer", Type: "boolean", Default: "true", Description: "Show a preview of the selected entry"},
+			{Name: "theme", Type: "string", Description: `Telescope theme to apply: "dropdown", "ivy", "cursor" or "" for none`},
+		},
+		files: concatFiles(
+			[]templateFile{
+				// go:embed skips directories starting with an underscore, hence no "_extensions" template dir
+				{outputPath: "lua/telescope/_extensions/{{.VarName}}.lua", tmplPath: "templates/telescope/lua/telescope/extensions/extension.lua.tmpl"},
+				{outputPath: "lua/{{.ModuleName}}/init.lua", tmplPath: "templates/telescope/lua/plugin_name/init.lua.tmpl"},
+				{outputPath: "lua/{{.ModuleName}}/picker.lua", tmplPath: "templates/telescope/lua/plugin_name/picker.lua.tmpl"},
+			},
+			moduleFiles,
+			testFiles,
+			docFiles,
+		),
+		partials: "templates/telescope/docs.tmpl",
+	},
+	{
+		Name:        "lsp",
+		Description: "Language server integration with LspAttach keymaps and handlers",
+		Options: []Option{
+			{Name: "server", Type: "string", Default: "example_ls", Description: "Name of the language server configuration"},
+			{Name: "cmd", Type: "table", Default: `{ "example-language-server", "--stdio" }`, Description: "Command starting the language server"},
+			{Name: "filetypes", Type: "table", Default: `{ "example" }`, Description: "Filetypes the server attaches to"},
+			{Name: "root_markers", Type: "table", Default: `{ ".git" }`, Description: "Files marking the root of a project"},
+			{Name: "settings", Type: "table", Default: "{}", Description: "Settings sent to the server"},
+			{Name: "keymaps", Type: "boolean", Default: "true", Description: "Set buffer-local keymaps when the server attaches"},
+		},
+		files: concatFiles(
+			[]templateFile{
+				{outputPath: "lua/{{.ModuleName}}/init.lua", tmplPath: "templates/lsp/lua/plugin_name/init.lua.tmpl"},
+				{outputPath: "lua/{{.ModuleName}}/lsp.lua", tmplPath: "templates/lsp/lua/plugin_name/lsp.lua.tmpl"},
+				{outputPath: "lua/{{.ModuleName}}/keymaps.lua", tmplPath: "templates/lsp/lua/plugin_name/keymaps.lua.tmpl"},
+				{outputPath: "lua/{{.ModuleName}}/handlers.lua", tmplPath: "templates/lsp/lua/plugin_name/handlers.lua.tmpl"},
+			},
+			moduleFiles,
+			testFiles,
+			docFiles,
+		),
+		partials: "templates/lsp/docs.tmpl",
+	},
+	{
+		Name:         "filetype",
+		Description:  "Language support: filetype detection, ftplugin and treesitter queries",
+		UsesFiletype: true,
+		Options: []Option{
+			{Name: "treesitter", Type: "boolean", Default: "true", Description: "Use treesitter highlighting when a parser is available"},
+		},
+		files: concatFiles(
+			[]templateFile{
+				{outputPath: "lua/{{.ModuleName}}/init.lua", tmplPath: "templates/lua/plugin_name/init.lua.tmpl"},
+				{outputPath: "ftdetect/{{.Filetype}}.lua", tmplPath: "templates/filetype/ftdetect/filetype.lua.tmpl"},
+				{outputPath: "ftplugin/{{.Filetype}}.lua", tmplPath: "templates/filetype/ftplugin/filetype.lua.tmpl"},
+				{outputPath: "queries/{{.Filetype}}/highlights.scm", tmplPath: "templates/filetype/queries/highlights.scm.tmpl"},
+				{outputPath: "queries/{{.Filetype}}/indents.scm", tmplPath: "templates/filetype/queries/indents.scm.tmpl"},
+				{outputPath: "queries/{{.Filetype}}/folds.scm", tmplPath: "templates/filetype/queries/folds.scm.tmpl"},
+				{outputPath: "after/syntax/{{.Filetype}}.vim", tmplPath: "templates/filetype/after/syntax/filetype.vim.tmpl"},
+			},
+			moduleFiles,
+			testFiles,
+			docFiles,
+		),
+		partials: "templates/filetype/docs.tmpl",
+	},
+	{
+		Name:        "statusline",
+		Description: "Statusline component for lualine and a plain %!v:lua statusline",
+		Options: []Option{
+			{Name: "icon", Type: "string", Default: "●", Description: "Icon shown before the component text"},
+			{Name: "highlight", Type: "string", Default: "Special", Description: "Highlight group of the component in statusline()"},
+		},
+		files: concatFiles(
+			[]templateFile{
+				{outputPath: "lua/{{.ModuleName}}/init.lua", tmplPath: "templates/statusline/lua/plugin_name/init.lua.tmpl"},
+				{outputPath: "lua/lualine/components/{{.VarName}}.lua", tmplPath: "templates/statusline/lua/lualine/components/component.lua.tmpl"},
+			},
+			moduleFiles,
+			testFiles,
+			docFiles,
+		),
+		partials: "templates/statusline/docs.tmpl",
+	},
+	{
+		Name:        "completion",
+		Description: "Completion source for nvim-cmp and blink.cmp",
+		Events:      []string{"InsertEnter"},
+		Options: []Option{
+			{Name: "trigger_characters", Type: "table", Default: `{ "." }`, Description: "Characters triggering completion"},
+			{Name: "filetypes", Type: "table", Default: "{}", Description: "Filetypes the source is available for, all when empty"},
+		},
+		files: concatFiles(
+			[]templateFile{
+				{outputPath: "lua/{{.ModuleName}}/init.lua", tmplPath: "templates/completion/lua/plugin_name/init.lua.tmpl"},
+				{outputPath: "lua/{{.ModuleName}}/source.lua", tmplPath: "templates/completion/lua/plugin_name/source.lua.tmpl"},
+				{outputPath: "lua/{{.ModuleName}}/blink.lua", tmplPath: "templates/completion/lua/plugin_name/blink.lua.tmpl"},
+			},
+			moduleFiles,
+			testFiles,
+			docFiles,
+		),
+		partials: "templates/completion/docs.tmpl",
+	},
+	{
+		Name:        "go",
+		Description: "Go remote plugin: a msgpack-RPC host started with jobstart()",
+		Commands:    []string{"", "LineCount"},
+		Options: []Option{
+			{Name: "binary", Type: "string", Description: "Path to the host binary, bin/<name> in the plugin directory when empty"},
+		},
+		files: concatFiles(
+			[]templateFile{
+				{outputPath: "go.mod", tmplPath: "templates/go/go.mod.tmpl"},
+				{outputPath: "main.go", tmplPath: "templates/go/main.go.tmpl"},
+				{outputPath: "handlers.go", tmplPath: "templates/go/handlers.go.tmpl"},
+				{outputPath: "Makefile", tmplPath: "templates/go/Makefile.tmpl"},
+				{outputPath: "lua/{{.ModuleName}}/init.lua", tmplPath: "templates/go/lua/plugin_name/init.lua.tmpl"},
+				{outputPath: "lua/{{.ModuleName}}/remote.lua", tmplPath: "templates/go/lua/plugin_name/remote.lua.tmpl"},
+				{outputPath: "plugin/{{.ModuleName}}.lua", tmplPath: "templates/go/plugin/plugin.lua.tmpl"},
+			},
+			moduleFiles,
+			testFiles,
+			docFiles,
+		),
+		partials: "templates/go/docs.tmpl",
+	},
+	{
+		Name:        "vim",
+		Description: "Vim script plugin with autoloaded functions, for Vim and Neovim",
+		OptionTypes: vimOptionTypes,
+		UsesActions: true,
+		Commands:    []string{""},
+		Options: []Option{
+			{Name: "enabled", Type: "boolean", Default: "true", Description: "Enable the plugin"},
+		},
+		files: []templateFile{
+			{outputPath: "plugin/{{.ModuleName}}.vim", tmplPath: "templates/vim/plugin/plugin.vim.tmpl"},
+			{outputPath: "autoload/{{.VarName}}.vim", tmplPath: "templates/vim/autoload/plugin.vim.tmpl"},
+			{outputPath: "lua/{{.ModuleName}}/health.lua", tmplPath: "templates/lua/plugin_name/health.lua.tmpl"},
+			{outputPath: "README.md", tmplPath: "templates/README.md.tmpl"},
+			{outputPath: "doc/{{.ModuleName}}.txt", tmplPath: "templates/doc/plugin.txt.tmpl"},
+			gitignoreFile,
+		},
+		partials: "templates/vim/docs.tmpl",
+	},
+	{
+		Name:        "mixed",
+		Description: "Vim script commands calling into Lua, with a Vim script fallback for Vim",
+		OptionTypes: vimOptionTypes,
+		UsesActions: true,
+		Commands:    []string{""},
+		Options: []Option{
+			{Name: "enabled", Type: "boolean", Default: "true", Description: "Enable the plugin"},
+		},
+		files: concatFiles(
+			[]templateFile{
+				{outputPath: "plugin/{{.ModuleName}}.vim", tmplPath: "templates/mixed/plugin/plugin.vim.tmpl"},
+				{outputPath: "autoload/{{.VarName}}.vim", tmplPath: "templates/mixed/autoload/plugin.vim.tmpl"},
+				{outputPath: "lua/{{.ModuleName}}/init.lua", tmplPath: "templates/mixed/lua/plugin_name/init.lua.tmpl"},
+			},
+			moduleFiles,
+			testFiles,
+			docFiles,
+		),
+		partials: "templates/mixed/docs.tmpl",
+	},
+}
+
+// ValidateOption checks that the flavor can render the option
+func (f Flavor) ValidateOption(option Option) error {
+	if err := option.Validate(); err != nil {
+		return err
+	}
+	// The flavor code relies on the type of its own options
+	for _, own := range f.Options {
+		if own.Name == option.Name && own.Type != option.Type {
+			return fmt.Errorf("invalid type %q for option %s: the %s flavor uses it as a %s", option.Type, option.Name, f.Name, own.Type)
+		}
+	}
+	if len(f.OptionTypes) == 0 {
+		return nil
+	}
+
+	for _, t := range f.OptionTypes {
+		if option.Type == t {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid type %q for option %s: the %s flavor only supports %s", option.Type, option.Name, f.Name, strings.Join(f.OptionTypes, ", "))
+}
+
+// ValidateOptions checks that the flavor can render every option and that
+// option names are distinct, as they share the defaults table
+func (f Flavor) ValidateOptions(options []Option) error {
+	seen := map[string]bool{}
+	for _, option := range options {
+		if err := f.ValidateOption(option); err != nil {
+			return err
+		}
+		if seen[option.Name] {
+			return fmt.Errorf("duplicate option %q", option.Name)
+		}
+		seen[option.Name] = true
+	}
+	return nil
+}
+
+// ValidateCommand checks that the user commands the flavor derives from the
+// command name of the plugin are valid
+func (f Flavor) ValidateCommand(name string) error {
+	for _, suffix := range f.Commands {
+		if err := ValidateCommand(name + suffix); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HasLuaModule reports whether the flavor generates a Lua module with setup()
+func (f Flavor) HasLuaModule() bool {
+	for _, file := range f.files {
+		if file.outputPath == "lua/{{.ModuleName}}/init.lua" {
+			return true
+		}
+	}
+	return false
+}
+
+// Flavors returns every available template set
+func Flavors() []Flavor {
+	return flavors
+}
+
+// LookupFlavor finds a template set by name
+// An empty name selects the default flavor
+func LookupFlavor(name string) (Flavor, error) {
+	if name == "" {
+		name = defaultFlavor
+	}
+
+	for _, flavor := range flavors {
+		if flavor.Name == name {
+			return flavor, nil
+		}
+	}
+
+	return Flavor{}, fmt.Errorf("unknown flavor %q: must be one of %s", name, strings.Join(FlavorNames(), ", "))
+}
+
+// FlavorNames returns the names of every available template set
+func FlavorNames() []string {
+	names := make([]string, 0, len(flavors))
+	for _, flavor := range flavors {
+		names = append(names, flavor.Name)
+	}
+	return names
+}
+
+// concatFiles joins several lists of template files into one
+func concatFiles(lists ...[]templateFile) []templateFile {
+	var files []templateFile
+	for _, list := range lists {
+		files = append(files, list...)
+	}
+	return files
+}
@@ -0,0 +1,129 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLookupFlavor(t *testing.T) {
+	// An empty name selects the default flavor
+	flavor, err := LookupFlavor("")
+	if err != nil {
+		t.Fatalf("LookupFlavor(\"\") failed: %v", err)
+	}
+	if flavor.Name != defaultFlavor {
+		t.Errorf("LookupFlavor(\"\") = %q, expected %q", flavor.Name, defaultFlavor)
+	}
+
+	for _, name := range FlavorNames() {
+		flavor, err := LookupFlavor(name)
+		if err != nil {
+			t.Errorf("LookupFlavor(%q) failed: %v", name, err)
+		}
+		if flavor.Name != name {
+			t.Errorf("LookupFlavor(%q) returned flavor %q", name, flavor.Name)
+		}
+	}
+
+	_, err = LookupFlavor("emacs")
+	if err == nil {
+		t.Fatalf("LookupFlavor(\"emacs\") should have returned an error")
+	}
+	if !strings.Contains(err.Error(), "colorscheme") {
+		t.Errorf("Unknown flavor error should list the available flavors, got %q", err)
+	}
+}
+
+func TestFlavorValidateOption(t *testing.T) {
+	lua, _ := LookupFlavor("lua")
+	vim, _ := LookupFlavor("vim")
+	table := Option{Name: "filetypes", Type: "table", Default: `{ "lua" }`}
+	boolean := Option{Name: "enabled", Type: "boolean", Default: "true"}
+
+	if err := lua.ValidateOption(table); err != nil {
+		t.Errorf("The lua flavor should accept table options, got %v", err)
+	}
+	if err := vim.ValidateOption(boolean); err != nil {
+		t.Errorf("The vim flavor should accept boolean options, got %v", err)
+	}
+	if err := vim.ValidateOption(table); err == nil {
+		t.Errorf("The vim flavor should reject table options")
+	}
+	if err := lua.ValidateOption(Option{Name: "bad-name", Type: "string"}); err == nil {
+		t.Errorf("ValidateOption should reject invalid options")
+	}
+
+	colorscheme, _ := LookupFlavor("colorscheme")
+	if err := colorscheme.ValidateOption(Option{Name: "variant", Type: "string", Default: "dark"}); err != nil {
+		t.Errorf("The colorscheme flavor should accept a new default for variant, got %v", err)
+	}
+	if err := colorscheme.ValidateOption(Option{Name: "variant", Type: "boolean"}); err == nil {
+		t.Errorf("The colorscheme flavor should reject a variant option of another type")
+	}
+}
+
+func TestFlavorValidateOptions(t *testing.T) {
+	lua, _ := LookupFlavor("lua")
+	options := []Option{{Name: "a", Type: "boolean"}, {Name: "b", Type: "number"}}
+
+	if err := lua.ValidateOptions(options); err != nil {
+		t.Errorf("ValidateOptions failed: %v", err)
+	}
+	if err := lua.ValidateOptions(append(options, Option{Name: "a", Type: "number"})); err == nil {
+		t.Errorf("ValidateOptions should reject duplicate option names")
+	}
+}
+
+func TestFlavorValidateCommand(t *testing.T) {
+	goFlavor, _ := LookupFlavor("go")
+	telescope, _ := LookupFlavor("telescope")
+
+	if err := goFlavor.ValidateCommand("Demo"); err != nil {
+		t.Errorf("The go flavor should accept Demo and DemoLineCount, got %v", err)
+	}
+	if err := goFlavor.ValidateCommand("Man"); err == nil {
+		t.Errorf("The go flavor should reject a command colliding with :Man")
+	}
+	if err := telescope.ValidateCommand("Man"); err != nil {
+		t.Errorf("The telescope flavor defines no command and should accept any name, got %v", err)
+	}
+}
+
+func TestFlavorTemplatesRender(t *testing.T) {
+	// Every template of every flavor must exist in the embedded FS and render
+	for _, flavor := range Flavors() {
+		data := TemplateData{
+			RepoName:    "test-plugin",
+			ModuleName:  "test-plugin",
+			Description: "A test plugin",
+			VarName:     "test_plugin",
+			CommandName: "TestPlugin",
+			AugroupName: "TestPlugin",
+			Options:     flavor.Options,
+			Flavor:      flavor.Name,
+		}
+
+		outputs := map[string]bool{}
+		for _, file := range flavor.files {
+			output, err := renderTemplateString(file.outputPath, file.outputPath, data)
+			if err != nil {
+				t.Errorf("%s: failed to render output path %s: %v", flavor.Name, file.outputPath, err)
+			}
+			if outputs[output] {
+				t.Errorf("%s: output path %s is generated twice", flavor.Name, output)
+			}
+			outputs[output] = true
+
+			if _, err := renderTemplateFile(file.tmplPath, data); err != nil {
+				t.Errorf("%s: failed to render %s: %v", flavor.Name, file.tmplPath, err)
+			}
+		}
+
+		// Default options must be valid so that generation never fails on them
+		for _, option := range flavor.Options {
+			if err := option.Validate(); err != nil {
+				t.Errorf("%s: invalid default option: %v", flavor.Name, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Test frameworks the tests of a generated plugin run with
+const (
+	testFrameworkPlenary = "plenary" // plenary.nvim, cloned by tests/minimal_init.lua
+	testFrameworkBusted  = "busted"  // busted inside Neovim with nlua, configured by .busted
+	testFrameworkNone    = "none"    // No test scaffold
+)
+
+// testFrameworks lists the supported test frameworks, the default one first
+var testFrameworks = []string{testFrameworkPlenary, testFrameworkBusted, testFrameworkNone}
+
+// TestFrameworkNames returns the names of the supported test frameworks
+func TestFrameworkNames() []string {
+	return append([]string(nil), testFrameworks...)
+}
+
+// ValidateTestFramework checks that a test framework is supported
+func ValidateTestFramework(name string) error {
+	if !containsString(testFrameworks, name) {
+		return fmt.Errorf("unknown test framework %q: must be one of %s", name, strings.Join(testFrameworks, ", "))
+	}
+	return nil
+}
+
+// withTestFramework adapts the test scaffold of a flavor to a test framework
+// busted needs no init file cloning plenary.nvim, and none drops the tests
+func withTestFramework(files []templateFile, framework string) []templateFile {
+	var result []templateFile
+	for _, file := range files {
+		switch {
+		case framework == testFrameworkNone && strings.HasPrefix(file.outputPath, "tests/"):
+			continue
+		case framework == testFrameworkBusted && file.outputPath == "tests/minimal_init.lua":
+			continue
+		}
+		result = append(result, file)
+	}
+	return result
+}
+
+// hasTestFiles reports whether the files include a test scaffold
+func hasTestFiles(files []templateFile) bool {
+	for _, file := range files {
+		if strings.HasPrefix(file.outputPath, "tests/") {
+			return true
+		}
+	}
+	return false
+}
@@ -9,6 +9,7 @@ import (
 	"strings"
 	"text/template"
 	"time"
+	"unicode"
 )
 
 //go:embed templates
@@ -16,97 +17,296 @@ var templateFS embed.FS
 
 // TemplateData holds all the variables used in templates
 type TemplateData struct {
-	Name           string // Plugin name
-	Description    string // Plugin description
-	Date           string // Current date
-	VarName        string // Sanitized variable name (for Lua)
-	CapitalizedCmd string // Capitalized first letter of name (for commands)
-	HeaderTitle    string // Uppercase title for docs
-	Underline      string // Underline for the header title
-	DocHeader      string // Header for the docs file
+	RepoName       string      // Repository and directory name, e.g. "foo.nvim"
+	ModuleName     string      // Lua module name (for require, doc tags and file names), e.g. "foo"
+	Description    string      // Plugin description
+	Date           string      // Current date
+	Year           string      // Current year, for the copyright notice of the LICENSE file
+	VarName        string      // Lua and Vim script identifier, e.g. "foo"
+	CommandName    string      // User command name, e.g. "Foo"
+	HeaderTitle    string      // Uppercase title for docs
+	Underline      string      // Underline for the header title
+	DocHeader      string      // Header for the docs file
+	Options        []Option    // Configuration options of the plugin
+	Flavor         string      // Name of the template set
+	Filetype       string      // Filetype added by the filetype flavor
+	Extensions     []string    // File extensions detected as Filetype
+	Actions        []Action    // Actions exposed as <Plug> mappings
+	Autocmds       bool        // Whether to generate the autocmds module
+	AugroupName    string      // PascalCase name (for the augroup and User events)
+	Commands       []string    // User commands defined by the entry point
+	Events         []string    // Events lazy.nvim loads the plugin on
+	Host           string      // Host of the plugin repository, e.g. github.com
+	Owner          string      // User or organization owning the plugin repository
+	PluginManagers []string    // Plugin managers the README shows installation snippets for
+	Author         string      // Author of the plugin, e.g. "Jane Doe <jane@example.com>"
+	License        string      // SPDX identifier of the license
+	LicenseFile    bool        // Whether the text of the license is written to LICENSE
+	Homepage       string      // URL of the plugin repository
+	Rockspec       bool        // Whether to generate the luarocks rockspec
+	LuaModules     []LuaModule // Lua modules of the generated files under lua/
+	RuntimeDirs    []string    // Other top-level directories loaded from the runtimepath
+	Tests          bool        // Whether the test scaffold is generated
+	TestFramework  string      // Framework the tests run with: plenary or busted
+}
+
+// PluginSpec describes the plugin to generate, as collected by the wizard or the CLI
+type PluginSpec struct {
+	Name          string   // Plugin name, the other names are derived from it
+	RepoName      string   // Repository and directory name, the plugin name when empty
+	ModuleName    string   // Lua module name, derived from the plugin name when empty
+	VarName       string   // Lua and Vim script identifier, derived from the module name when empty
+	CommandName   string   // User command name, derived from the module name when empty
+	Description   string   // Plugin description
+	Options       []Option // Configuration options of the plugin
+	Flavor        string   // Name of the template set, empty for the default
+	Filetype      string   // Filetype added by flavors that use one
+	Extensions    []string // File extensions detected as Filetype
+	Actions       []Action // Actions exposed as <Plug> mappings
+	Autocmds      bool     // Whether to generate the autocmds module
+	Host          string   // Host of the plugin repository, github.com when empty
+	Owner         string   // Owner of the plugin repository, read from its git remote when empty
+	Installs      []string // Plugin managers to show installation snippets for, all when empty
+	Author        string   // Author of the plugin
+	License       string   // SPDX identifier of the license, MIT when empty
+	Rockspec      bool     // Whether to generate a luarocks rockspec and release workflow
+	GitHubUser    string   // Owner of the repository on github.com when neither Owner nor a git remote gives one
+	OutputDir     string   // Directory the plugin directory is created in, the working directory when empty
+	TestFramework string   // Framework the tests run with, plenary when empty
+	GitInit       bool     // Whether to initialize a git repository and commit the generated files
 }
 
 // GeneratePlugin creates a new Neovim plugin with the given name and description
 // It builds the directory structure and generates all necessary files
 func GeneratePlugin(name, description string) error {
+	return Generate(PluginSpec{Name: name, Description: description})
+}
+
+// Generate creates a new Neovim plugin from a full plugin spec
+func Generate(spec PluginSpec) error {
+	name := spec.Name
+	if err := ValidateName(name); err != nil {
+		return err
+	}
+	names, err := spec.Names()
+	if err != nil {
+		return err
+	}
+
+	// Resolve the template set, adding the options declared by the user to
+	// its default option schema
+	flavor, err := LookupFlavor(spec.Flavor)
+	if err != nil {
+		return err
+	}
+	if err := flavor.ValidateCommand(names.Command); err != nil {
+		return err
+	}
+	spec.Options = mergeOptions(flavor.Options, spec.Options)
+
+	// Flavors adding a language need a filetype, defaulting to the plugin name,
+	// and the extensions detecting it, defaulting to the filetype itself
+	if flavor.UsesFiletype {
+		if spec.Filetype == "" {
+			spec.Filetype = strings.ToLower(names.VarName)
+		}
+		if err := ValidateFiletype(spec.Filetype); err != nil {
+			return err
+		}
+		if len(spec.Extensions) == 0 {
+			spec.Extensions = []string{spec.Filetype}
+		}
+	}
+
+	// Actions need an entry point defining their <Plug> mappings
+	if err := validateActions(flavor, spec.Actions); err != nil {
+		return err
+	}
+	spec.Options = withDefaultKeymapsOption(spec.Options, spec.Actions)
+
+	// The autocmds module is set up by the Lua module of the flavor
+	files := flavor.files
+	if spec.Autocmds {
+		if !flavor.HasLuaModule() {
+			return fmt.Errorf("the %s flavor does not support autocommands", flavor.Name)
+		}
+		files = concatFiles(files, []templateFile{autocmdsFile})
+	}
+
+	// The test scaffold follows the test framework, plenary.nvim by default
+	if spec.TestFramework == "" {
+		spec.TestFramework = testFrameworkPlenary
+	}
+	if err := ValidateTestFramework(spec.TestFramework); err != nil {
+		return err
+	}
+	files = withTestFramework(files, spec.TestFramework)
+
+	// The rockspec installs whatever the flavor generates, and runs its tests
+	// with busted like the busted test framework. Plugins without a Lua module
+	// are left to the plugin managers installing from the repository
+	if spec.Rockspec {
+		if !flavor.HasLuaModule() {
+			return fmt.Errorf("the %s flavor does not support a rockspec", flavor.Name)
+		}
+		files = concatFiles(files, rockspecFiles)
+	}
+	if (spec.Rockspec || spec.TestFramework == testFrameworkBusted) && hasTestFiles(files) {
+		files = concatFiles(files, []templateFile{bustedFile})
+	}
+	if spec.License == "" {
+		spec.License = defaultLicense
+	}
+	// Write the text of the built-in licenses, under their canonical identifier
+	license, hasLicenseText := LookupLicense(spec.License)
+	if hasLicenseText {
+		spec.License = license.ID
+		files = concatFiles(files, []templateFile{licenseFile(license)})
+	}
+
+	// Reject option schemas that would render invalid Lua or Vim script
+	if err := flavor.ValidateOptions(spec.Options); err != nil {
+		return err
+	}
+
+	// Default to the remote of an existing clone, e.g. one of an empty repository
+	pluginDir := PluginDir(spec.OutputDir, names.Repo)
+	if spec.Owner == "" {
+		if host, owner, ok := readGitRemote(pluginDir); ok {
+			spec.Host, spec.Owner = host, owner
+		}
+	}
+	// Then to the GitHub user of the config file for repositories on GitHub
+	if spec.Owner == "" && (spec.Host == "" || spec.Host == defaultHost) {
+		spec.Owner = spec.GitHubUser
+	}
+	if spec.Host == "" {
+		spec.Host = defaultHost
+	}
+	if err := ValidateHost(spec.Host); err != nil {
+		return err
+	}
+	if spec.Owner != "" {
+		if err := ValidateOwner(spec.Owner); err != nil {
+			return err
+		}
+	}
+	if len(spec.Installs) == 0 {
+		spec.Installs = PluginManagerNames()
+	}
+	for _, manager := range spec.Installs {
+		if !isPluginManager(manager) {
+			return fmt.Errorf("unknown plugin manager %q: must be one of %s", manager, strings.Join(PluginManagerNames(), ", "))
+		}
+	}
+	// Report a missing git before generating anything
+	if spec.GitInit {
+		if _, err := lookGit(); err != nil {
+			return err
+		}
+	}
+
 	// Create the main plugin directory
-	pluginDir := "./" + name
 	if err := os.MkdirAll(pluginDir, 0o755); err != nil {
 		return fmt.Errorf("failed to create plugin directory: %w", err)
 	}
 
-	// Create standard Neovim plugin directory structure:
-	// - lua/{name}: contains the main plugin code
-	// - plugin: contains the plugin entry point
-	// - doc: contains plugin documentation
-	dirs := []string{
-		filepath.Join(pluginDir, "lua", name),
-		filepath.Join(pluginDir, "plugin"),
-		filepath.Join(pluginDir, "doc"),
+	// Prepare template data
+	now := time.Now()
+	data := TemplateData{
+		RepoName:       names.Repo,
+		ModuleName:     names.Module,
+		Description:    spec.Description,
+		Date:           now.Format("2006-01-02"),
+		Year:           now.Format("2006"),
+		VarName:        names.VarName,
+		CommandName:    names.Command,
+		HeaderTitle:    strings.ToUpper(names.Module),
+		DocHeader:      strings.ToUpper(names.Module) + ".TXT",
+		Underline:      underline(strings.ToUpper(names.Module)),
+		Options:        spec.Options,
+		Flavor:         flavor.Name,
+		Filetype:       spec.Filetype,
+		Extensions:     spec.Extensions,
+		Actions:        spec.Actions,
+		Autocmds:       spec.Autocmds,
+		AugroupName:    pascalCase(names.Module),
+		Events:         flavor.Events,
+		Host:           spec.Host,
+		Owner:          spec.Owner,
+		PluginManagers: spec.Installs,
+		Author:         spec.Author,
+		License:        spec.License,
+		LicenseFile:    hasLicenseText,
+		Rockspec:       spec.Rockspec,
+		TestFramework:  spec.TestFramework,
+	}
+	data.Homepage = data.RepoURL()
+	for _, suffix := range flavor.Commands {
+		data.Commands = append(data.Commands, data.CommandName+suffix)
+	}
+	// The autocmds module only runs once the plugin is loaded
+	if spec.Autocmds {
+		data.Events = append(append([]string(nil), data.Events...), autocmdsEvent)
 	}
 
-	// Create each directory with proper permissions
-	for _, dir := range dirs {
-		if err := os.MkdirAll(dir, 0o755); err != nil {
-			return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	// Render the output paths first, the rockspec maps every file under lua/
+	relPaths := make([]string, 0, len(files))
+	for _, file := range files {
+		relPath, err := renderTemplateString(file.outputPath, file.outputPath, data)
+		if err != nil {
+			return fmt.Errorf("failed to render output path %s: %w", file.outputPath, err)
 		}
+		relPaths = append(relPaths, relPath)
 	}
+	data.LuaModules = luaModules(relPaths)
+	data.RuntimeDirs = runtimeDirs(relPaths)
+	data.Tests = hasTests(relPaths)
+
+	// Generate each file of the flavor from its template file
+	// The directory structure (lua/{name}, plugin, doc, ...) follows from the output paths
+	for i, file := range files {
+		outputPath := filepath.Join(pluginDir, filepath.FromSlash(relPaths[i]))
+
+		if err := os.MkdirAll(filepath.Dir(outputPath), 0o755); err != nil {
+			return fmt.Errorf("failed to create directory %s: %w", filepath.Dir(outputPath), err)
+		}
 
-	// Prepare template data
-	data := TemplateData{
-		Name:           name,
-		Description:    description,
-		Date:           time.Now().Format("2006-01-02"),
-		VarName:        sanitizeVarName(name),
-		CapitalizedCmd: capitalizeFirst(name),
-		HeaderTitle:    strings.ToUpper(name),
-		DocHeader:      strings.ToUpper(name) + ".TXT",
-		Underline:      strings.Repeat("=", len(strings.ToUpper(name))),
-	}
-
-	// Define the files to generate
-	filesToGenerate := []struct {
-		outputPath string
-		tmplPath   string
-	}{
-		{
-			outputPath: filepath.Join(pluginDir, "lua", name, "init.lua"),
-			tmplPath:   "templates/lua/plugin_name/init.lua.tmpl",
-		},
-		{
-			outputPath: filepath.Join(pluginDir, "plugin", name+".lua"),
-			tmplPath:   "templates/plugin/plugin.lua.tmpl",
-		},
-		{
-			outputPath: filepath.Join(pluginDir, "README.md"),
-			tmplPath:   "templates/README.md.tmpl",
-		},
-		{
-			outputPath: filepath.Join(pluginDir, "doc", name+".txt"),
-			tmplPath:   "templates/doc/plugin.txt.tmpl",
-		},
-		{
-			outputPath: filepath.Join(pluginDir, ".stylua.toml"),
-			tmplPath:   "templates/stylua.toml.tmpl",
-		},
-	}
-
-	// Generate each file from its template file
-	for _, file := range filesToGenerate {
 		content, err := renderTemplateFile(file.tmplPath, data)
 		if err != nil {
-			return fmt.Errorf("failed to render template for %s: %w", file.outputPath, err)
+			return fmt.Errorf("failed to render template for %s: %w", outputPath, err)
+		}
+
+		if err := writeFile(outputPath, content); err != nil {
+			return fmt.Errorf("failed to write file %s: %w", outputPath, err)
 		}
+	}
 
-		if err := writeFile(file.outputPath, content); err != nil {
-			return fmt.Errorf("failed to write file %s: %w", file.outputPath, err)
+	// Commit the generated files, with the repository as origin once its owner is known
+	if spec.GitInit {
+		remoteURL := ""
+		if spec.Owner != "" {
+			remoteURL = data.RepoURL() + ".git"
+		}
+		if err := initRepository(pluginDir, remoteURL); err != nil {
+			return fmt.Errorf("plugin generated in %s, but the git repository was not initialized: %w", pluginDir, err)
 		}
 	}
 
 	return nil
 }
 
+// PluginDir returns the directory a plugin is generated in
+func PluginDir(outputDir, repoName string) string {
+	if outputDir == "" {
+		return "./" + repoName
+	}
+	return filepath.Join(outputDir, repoName)
+}
+
 // renderTemplateFile loads a template from the embedded filesystem and renders it
+// Shared templates such as the README and vimdoc may have their blocks
+// overridden by the partials of the selected flavor
 func renderTemplateFile(tmplPath string, data TemplateData) (string, error) {
 	// Read the template file from the embedded filesystem
 	tmplContent, err := templateFS.ReadFile(tmplPath)
@@ -120,6 +320,21 @@ func renderTemplateFile(tmplPath string, data TemplateData) (string, error) {
 		return "", fmt.Errorf("failed to parse template %s: %w", tmplPath, err)
 	}
 
+	// Parse the flavor partials after the template so their definitions win
+	flavor, err := LookupFlavor(data.Flavor)
+	if err != nil {
+		return "", err
+	}
+	if flavor.partials != "" {
+		partials, err := templateFS.ReadFile(flavor.partials)
+		if err != nil {
+			return "", fmt.Errorf("failed to read template file %s: %w", flavor.partials, err)
+		}
+		if _, err := tmpl.New(filepath.Base(flavor.partials)).Parse(string(partials)); err != nil {
+			return "", fmt.Errorf("failed to parse template %s: %w", flavor.partials, err)
+		}
+	}
+
 	// Execute the template with the data
 	var buf bytes.Buffer
 	if err := tmpl.Execute(&buf, data); err != nil {
@@ -129,6 +344,21 @@ func renderTemplateFile(tmplPath string, data TemplateData) (string, error) {
 	return buf.String(), nil
 }
 
+// renderTemplateString renders an inline template such as an output path
+func renderTemplateString(name, text string, data TemplateData) (string, error) {
+	tmpl, err := template.New(name).Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
 // writeFile is a helper function to write content to a file
 func writeFile(path, content string) error {
 	return os.WriteFile(path, []byte(content), 0o644)
@@ -137,18 +367,27 @@ func writeFile(path, content string) error {
 // Helper functions for string manipulation
 
 // sanitizeVarName converts plugin name to a valid Lua variable name
-// Replaces hyphens with underscores for use in Lua variables
+// Replaces hyphens, dots and other characters with underscores for use in Lua variables
 func sanitizeVarName(name string) string {
 	// Replace non-alphanumeric characters with underscore
-	return strings.ReplaceAll(name, "-", "_")
+	return strings.Map(func(r rune) rune {
+		if r == '_' || r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return r
+		}
+		return '_'
+	}, name)
 }
 
-// capitalizeFirst capitalizes the first letter of a string
-// Used for command names and other user-facing identifiers
-func capitalizeFirst(s string) string {
-	if s == "" {
-		return ""
+// pascalCase joins the words of a name with their first letter capitalized,
+// e.g. "my-plugin.nvim" becomes "MyPluginNvim". Words are separated by any
+// character that is not a letter, combining mark or digit. Non-ASCII letters
+// are kept, so the result is not a valid user command name for "café"
+func pascalCase(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.Is(unicode.M, r)
+	})
+	for i, word := range words {
+		words[i] = capitalizeFirst(word)
 	}
-	return strings.ToUpper(s[:1]) + s[1:]
+	return strings.Join(words, "")
 }
-
@@ -2,6 +2,7 @@ package ui
 
 import (
 	"embed"
+	"encoding/json"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -21,6 +22,7 @@ func TestSanitizeVarName(t *testing.T) {
 		{"plugin_name", "plugin_name"},
 		{"camelCase", "camelCase"},
 		{"plugin-with-many-hyphens", "plugin_with_many_hyphens"},
+		{"foo.nvim", "foo_nvim"},
 		{"", ""},
 	}
 
@@ -41,6 +43,8 @@ func TestCapitalizeFirst(t *testing.T) {
 		{"Hello", "Hello"},
 		{"camelCase", "CamelCase"},
 		{"123test", "123test"}, // Numbers not affected
+		{"éclair", "Éclair"},
+		{"e\u0301clair", "E\u0301clair"}, // Combining accent kept with its letter
 		{"", ""},
 	}
 
@@ -52,25 +56,48 @@ func TestCapitalizeFirst(t *testing.T) {
 	}
 }
 
+func TestPascalCase(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"my-plugin", "MyPlugin"},
+		{"my_plugin.nvim", "MyPluginNvim"},
+		{"nvim-cmp2", "NvimCmp2"},
+		{"Telescope", "Telescope"},
+		{"café-au-lait", "CaféAuLait"},
+		{"cafe\u0301", "Cafe\u0301"},
+		{"", ""},
+	}
+
+	for _, test := range tests {
+		result := pascalCase(test.input)
+		if result != test.expected {
+			t.Errorf("pascalCase(%q) = %q, expected %q", test.input, result, test.expected)
+		}
+	}
+}
+
 func TestRenderTemplateFile(t *testing.T) {
 	// First verify the template file exists in the file system
 	_, err := os.Stat("../../templates/README.md.tmpl")
 	if err != nil {
 		t.Skipf("Skipping test: template file not found: %v", err)
 	}
-	
+
 	data := TemplateData{
-		Name:           "test-plugin",
-		Description:    "A test plugin",
-		VarName:        "test_plugin",
-		CapitalizedCmd: "Test-plugin",
+		RepoName:    "test-plugin",
+		ModuleName:  "test-plugin",
+		Description: "A test plugin",
+		VarName:     "test_plugin",
+		CommandName: "TestPlugin",
 	}
-	
+
 	result, err := renderTemplateFile("templates/README.md.tmpl", data)
 	if err != nil {
 		t.Fatalf("renderTemplateFile failed: %v", err)
 	}
-	
+
 	// Verify the rendered content contains expected elements
 	expectedElements := []string{
 		"# test-plugin",
@@ -78,7 +105,7 @@ func TestRenderTemplateFile(t *testing.T) {
 		"require('test-plugin')",
 		":Test-plugin",
 	}
-	
+
 	for _, expected := range expectedElements {
 		if !strings.Contains(result, expected) {
 			t.Errorf("Rendered template missing expected content: %q", expected)
@@ -96,14 +123,14 @@ func TestGeneratePlugin(t *testing.T) {
 		"templates/doc/plugin.txt.tmpl",
 		"templates/.stylua.toml.tmpl",
 	}
-	
+
 	for _, path := range templatePaths {
 		if _, err := os.Stat(path); os.IsNotExist(err) {
 			t.Logf("Warning: Template file not found locally: %s", path)
 			missingFiles = true
 		}
 	}
-	
+
 	if missingFiles {
 		t.Skip("Skipping test because some template files are missing")
 	}
@@ -152,9 +179,13 @@ func TestGeneratePlugin(t *testing.T) {
 
 	expectedFiles := []string{
 		filepath.Join(tempDir, pluginName, "lua", pluginName, "init.lua"),
+		filepath.Join(tempDir, pluginName, "lua", pluginName, "config.lua"),
+		filepath.Join(tempDir, pluginName, "lua", pluginName, "types.lua"),
+		filepath.Join(tempDir, pluginName, "lua", pluginName, "health.lua"),
 		filepath.Join(tempDir, pluginName, "plugin", pluginName+".lua"),
 		filepath.Join(tempDir, pluginName, "README.md"),
 		filepath.Join(tempDir, pluginName, "doc", pluginName+".txt"),
+		filepath.Join(tempDir, pluginName, ".luarc.json"),
 	}
 
 	for _, file := range expectedFiles {
@@ -227,7 +258,7 @@ func TestBasicTemplates(t *testing.T) {
 		"templates/README.md.tmpl",
 		"templates/doc/plugin.txt.tmpl",
 	}
-	
+
 	for _, path := range templatePaths {
 		// Skip if files don't exist locally during development or testing
 		_, err := os.Stat(path)
@@ -235,7 +266,7 @@ func TestBasicTemplates(t *testing.T) {
 			t.Logf("Skipping test for template %s: not found locally", path)
 			continue
 		}
-		
+
 		// Check if the file exists in the embedded filesystem
 		_, err = templateFS.ReadFile(path)
 		if err != nil {
@@ -251,7 +282,7 @@ func TestFullTemplateSet(t *testing.T) {
 	if os.Getenv("RUN_ALL_TESTS") != "1" {
 		t.Skip("Skipping full template test; set RUN_ALL_TESTS=1 to run")
 	}
-	
+
 	// This tests all template files including optional ones
 	templatePaths := []string{
 		"templates/lua/plugin_name/init.lua.tmpl",
@@ -260,7 +291,7 @@ func TestFullTemplateSet(t *testing.T) {
 		"templates/doc/plugin.txt.tmpl",
 		"templates/.stylua.toml.tmpl",
 	}
-	
+
 	for _, path := range templatePaths {
 		// Check if the file exists in the embedded filesystem
 		_, err := templateFS.ReadFile(path)
@@ -268,4 +299,900 @@ func TestFullTemplateSet(t *testing.T) {
 			t.Errorf("Template file not found or not readable in embedded FS: %s, error: %v", path, err)
 		}
 	}
-}
\ No newline at end of file
+}
+func TestLuaLSTemplates(t *testing.T) {
+	data := TemplateData{
+		RepoName:    "test-plugin",
+		ModuleName:  "test-plugin",
+		Description: "A test plugin",
+		VarName:     "test_plugin",
+	}
+
+	// The main module and the meta file should agree on the config class names
+	expected := map[string][]string{
+		"templates/lua/plugin_name/init.lua.tmpl": {
+			"---@type test-plugin.Config",
+			"---@param opts? test-plugin.UserConfig",
+		},
+		"templates/lua/plugin_name/types.lua.tmpl": {
+			"---@meta",
+			"---@class test-plugin.UserConfig",
+			"---@class test-plugin.Config",
+		},
+	}
+
+	for tmplPath, elements := range expected {
+		result, err := renderTemplateFile(tmplPath, data)
+		if err != nil {
+			t.Fatalf("renderTemplateFile(%s) failed: %v", tmplPath, err)
+		}
+		for _, element := range elements {
+			if !strings.Contains(result, element) {
+				t.Errorf("%s missing expected content: %q", tmplPath, element)
+			}
+		}
+	}
+
+	// .luarc.json must be valid JSON configured for the Neovim runtime
+	luarc, err := renderTemplateFile("templates/luarc.json.tmpl", data)
+	if err != nil {
+		t.Fatalf("renderTemplateFile(luarc) failed: %v", err)
+	}
+	var settings map[string]interface{}
+	if err := json.Unmarshal([]byte(luarc), &settings); err != nil {
+		t.Fatalf(".luarc.json is not valid JSON: %v", err)
+	}
+	if settings["runtime.version"] != "LuaJIT" {
+		t.Errorf(".luarc.json runtime.version = %v, expected LuaJIT", settings["runtime.version"])
+	}
+}
+
+func TestOptionTemplates(t *testing.T) {
+	data := TemplateData{
+		RepoName:    "test-plugin",
+		ModuleName:  "test-plugin",
+		Description: "A test plugin",
+		Options: []Option{
+			{Name: "enabled", Type: "boolean", Default: "true", Description: "Enable the plugin"},
+			{Name: "border", Type: "string", Default: "rounded", Description: "Border of floating windows"},
+		},
+	}
+
+	// Every template rendering the configuration must agree on the defaults
+	expected := map[string][]string{
+		"templates/lua/plugin_name/config.lua.tmpl": {
+			"enabled = true,",
+			`border = "rounded",`,
+			`vim.tbl_deep_extend("force", {}, M.defaults, opts or {})`,
+			`validate("enabled", M.options.enabled, "boolean")`,
+			`validate("border", M.options.border, "string")`,
+		},
+		"templates/lua/plugin_name/types.lua.tmpl": {
+			"---@field enabled? boolean Enable the plugin",
+			"---@field border string Border of floating windows",
+		},
+		"templates/README.md.tmpl": {
+			"enabled = true,",
+			`border = "rounded",`,
+			"| `border` | `string` | `\"rounded\"` | Border of floating windows |",
+		},
+		"templates/doc/plugin.txt.tmpl": {
+			"enabled = true,",
+			`border = "rounded",`,
+			"*test-plugin-option-border*",
+			"Border of floating windows",
+		},
+	}
+
+	for tmplPath, elements := range expected {
+		result, err := renderTemplateFile(tmplPath, data)
+		if err != nil {
+			t.Fatalf("renderTemplateFile(%s) failed: %v", tmplPath, err)
+		}
+		for _, element := range elements {
+			if !strings.Contains(result, element) {
+				t.Errorf("%s missing expected content: %q", tmplPath, element)
+			}
+		}
+	}
+
+	// Without options the templates keep their placeholders and skip validation
+	data.Options = nil
+	result, err := renderTemplateFile("templates/lua/plugin_name/config.lua.tmpl", data)
+	if err != nil {
+		t.Fatalf("renderTemplateFile(config.lua) failed: %v", err)
+	}
+	if !strings.Contains(result, "-- Define your default options here") {
+		t.Errorf("config.lua without options should keep the placeholder comment")
+	}
+	if strings.Contains(result, "local function validate") {
+		t.Errorf("config.lua without options should not define a validate helper")
+	}
+}
+
+// generateInTempDir generates a plugin from spec inside a temporary directory
+// and returns the path of the generated plugin
+func generateInTempDir(t *testing.T, spec PluginSpec) string {
+	t.Helper()
+	tempDir := t.TempDir()
+
+	// Change to the temporary directory so Generate creates files there
+	originalDir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get current directory: %v", err)
+	}
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Failed to change to temp directory: %v", err)
+	}
+	defer os.Chdir(originalDir)
+
+	if err := Generate(spec); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	return filepath.Join(tempDir, spec.Name)
+}
+
+func TestGenerateColorscheme(t *testing.T) {
+	pluginDir := generateInTempDir(t, PluginSpec{Name: "test-theme", Description: "A test colorscheme", Flavor: "colorscheme"})
+	expectedFiles := []string{
+		filepath.Join(pluginDir, "colors", "test-theme.lua"),
+		filepath.Join(pluginDir, "colors", "test-theme-dark.lua"),
+		filepath.Join(pluginDir, "colors", "test-theme-light.lua"),
+		filepath.Join(pluginDir, "lua", "test-theme", "init.lua"),
+		filepath.Join(pluginDir, "lua", "test-theme", "palette.lua"),
+		filepath.Join(pluginDir, "lua", "test-theme", "groups.lua"),
+		filepath.Join(pluginDir, "lua", "test-theme", "config.lua"),
+		filepath.Join(pluginDir, "doc", "test-theme.txt"),
+		filepath.Join(pluginDir, "README.md"),
+	}
+
+	for _, file := range expectedFiles {
+		if _, err := os.Stat(file); os.IsNotExist(err) {
+			t.Errorf("Expected file %s to exist", file)
+		}
+	}
+
+	// A colorscheme has no user command entry point
+	if _, err := os.Stat(filepath.Join(pluginDir, "plugin", "test-theme.lua")); !os.IsNotExist(err) {
+		t.Errorf("Colorscheme should not generate a plugin entry point")
+	}
+
+	// The flavor's default options are used when none are declared
+	config, err := os.ReadFile(filepath.Join(pluginDir, "lua", "test-theme", "config.lua"))
+	if err != nil {
+		t.Fatalf("Failed to read config.lua: %v", err)
+	}
+	if !strings.Contains(string(config), `variant = "auto",`) {
+		t.Errorf("config.lua should contain the colorscheme's default options")
+	}
+
+	// The vimdoc lists the same highlight groups as groups.lua
+	doc, err := os.ReadFile(filepath.Join(pluginDir, "doc", "test-theme.txt"))
+	if err != nil {
+		t.Fatalf("Failed to read vimdoc: %v", err)
+	}
+	groups, err := os.ReadFile(filepath.Join(pluginDir, "lua", "test-theme", "groups.lua"))
+	if err != nil {
+		t.Fatalf("Failed to read groups.lua: %v", err)
+	}
+	for _, category := range highlightCategories {
+		for _, group := range category.Groups {
+			if !strings.Contains(string(doc), "  "+group.Name+"\n") {
+				t.Errorf("vimdoc should list highlight group %s", group.Name)
+			}
+			if !strings.Contains(string(groups), `["`+group.Name+`"] = `+group.Spec) {
+				t.Errorf("groups.lua should define highlight group %s", group.Name)
+			}
+		}
+	}
+	if !strings.Contains(string(doc), "*test-theme-highlight-groups*") {
+		t.Errorf("vimdoc should contain the highlight groups section")
+	}
+}
+
+func TestGenerateTelescopeExtension(t *testing.T) {
+	pluginDir := generateInTempDir(t, PluginSpec{Name: "test-picker", Description: "A test picker", Flavor: "telescope"})
+
+	// Extension names are Lua identifiers, so they use the sanitized variable name
+	extension, err := os.ReadFile(filepath.Join(pluginDir, "lua", "telescope", "_extensions", "test_picker.lua"))
+	if err != nil {
+		t.Fatalf("Failed to read telescope extension: %v", err)
+	}
+	for _, expected := range []string{
+		"telescope.register_extension(",
+		"test_picker = function(opts)",
+		`require("test-picker.picker").pick(opts)`,
+	} {
+		if !strings.Contains(string(extension), expected) {
+			t.Errorf("Telescope extension missing expected content: %q", expected)
+		}
+	}
+
+	picker, err := os.ReadFile(filepath.Join(pluginDir, "lua", "test-picker", "picker.lua"))
+	if err != nil {
+		t.Fatalf("Failed to read picker module: %v", err)
+	}
+	for _, expected := range []string{"M.finder = ", "M.sorter = ", "M.previewer = ", "M.pick = "} {
+		if !strings.Contains(string(picker), expected) {
+			t.Errorf("Picker module missing expected stub: %q", expected)
+		}
+	}
+
+	readme, err := os.ReadFile(filepath.Join(pluginDir, "README.md"))
+	if err != nil {
+		t.Fatalf("Failed to read README.md: %v", err)
+	}
+	if !strings.Contains(string(readme), `require("telescope").load_extension("test_picker")`) {
+		t.Errorf("README.md should explain how to load the extension")
+	}
+}
+
+func TestGenerateLSP(t *testing.T) {
+	pluginDir := generateInTempDir(t, PluginSpec{Name: "test-lsp", Description: "A test LSP plugin", Flavor: "lsp"})
+
+	expected := map[string][]string{
+		filepath.Join("lua", "test-lsp", "init.lua"): {
+			`vim.api.nvim_create_autocmd("LspAttach"`,
+			`require("test-lsp.lsp").register(M.options)`,
+		},
+		filepath.Join("lua", "test-lsp", "lsp.lua"): {
+			"vim.lsp.config(options.server, config)",
+			"vim.lsp.enable(options.server)",
+			// Fallback for Neovim versions without vim.lsp.config
+			`vim.api.nvim_create_autocmd("FileType"`,
+			"vim.lsp.start(",
+		},
+		filepath.Join("lua", "test-lsp", "keymaps.lua"): {
+			"buffer = bufnr",
+		},
+		filepath.Join("lua", "test-lsp", "handlers.lua"): {
+			`["window/showMessage"]`,
+		},
+		filepath.Join("lua", "test-lsp", "health.lua"): {
+			`health.start("test-lsp")`,
+			"vim.fn.executable(cmd[1])",
+		},
+		filepath.Join("lua", "test-lsp", "config.lua"): {
+			`cmd = { "example-language-server", "--stdio" },`,
+			`validate("keymaps", M.options.keymaps, "boolean")`,
+		},
+		filepath.Join("doc", "test-lsp.txt"): {
+			"*test-lsp-server*",
+			"*test-lsp-keymaps*",
+			"*test-lsp-handlers*",
+			"*test-lsp-health*",
+		},
+	}
+
+	for file, elements := range expected {
+		content, err := os.ReadFile(filepath.Join(pluginDir, file))
+		if err != nil {
+			t.Errorf("Failed to read %s: %v", file, err)
+			continue
+		}
+		for _, element := range elements {
+			if !strings.Contains(string(content), element) {
+				t.Errorf("%s missing expected content: %q", file, element)
+			}
+		}
+	}
+}
+
+func TestGenerateFiletype(t *testing.T) {
+	pluginDir := generateInTempDir(t, PluginSpec{
+		Name:        "test-lang",
+		Description: "A test language plugin",
+		Flavor:      "filetype",
+		Filetype:    "testlang",
+		Extensions:  []string{"tl", "tli"},
+	})
+
+	expected := map[string][]string{
+		filepath.Join("ftdetect", "testlang.lua"): {
+			`["tl"] = "testlang",`,
+			`["tli"] = "testlang",`,
+		},
+		filepath.Join("ftplugin", "testlang.lua"): {
+			"vim.b.did_ftplugin = true",
+			`pcall(vim.treesitter.start, 0, "testlang")`,
+			"vim.b.undo_ftplugin",
+		},
+		filepath.Join("queries", "testlang", "highlights.scm"): {},
+		filepath.Join("queries", "testlang", "indents.scm"):    {},
+		filepath.Join("queries", "testlang", "folds.scm"):      {},
+		filepath.Join("after", "syntax", "testlang.vim"): {
+			`let b:current_syntax = "testlang"`,
+		},
+		filepath.Join("lua", "test-lang", "health.lua"): {
+			"vim.treesitter.language.add",
+		},
+		filepath.Join("doc", "test-lang.txt"): {
+			"*test-lang-filetype*",
+			"*test-lang-queries*",
+		},
+	}
+
+	for file, elements := range expected {
+		content, err := os.ReadFile(filepath.Join(pluginDir, file))
+		if err != nil {
+			t.Errorf("Failed to read %s: %v", file, err)
+			continue
+		}
+		for _, element := range elements {
+			if !strings.Contains(string(content), element) {
+				t.Errorf("%s missing expected content: %q", file, element)
+			}
+		}
+	}
+}
+
+func TestGenerateStatusline(t *testing.T) {
+	pluginDir := generateInTempDir(t, PluginSpec{Name: "test-status", Description: "A test statusline", Flavor: "statusline"})
+
+	expected := map[string][]string{
+		filepath.Join("lua", "test-status", "init.lua"): {
+			"M.status = function()",
+			"M.statusline = function()",
+		},
+		filepath.Join("lua", "lualine", "components", "test_status.lua"): {
+			`require("lualine.component"):extend()`,
+			`return require("test-status").status()`,
+		},
+		filepath.Join("tests", "test-status_spec.lua"): {
+			`plugin.setup({ icon = 1 })`,
+			"plugin.statusline()",
+		},
+		filepath.Join("doc", "test-status.txt"): {
+			"*test-status-statusline*",
+			`%!v:lua.require'test-status'.statusline()`,
+		},
+	}
+
+	assertFilesContain(t, pluginDir, expected)
+}
+
+func TestGenerateCompletion(t *testing.T) {
+	pluginDir := generateInTempDir(t, PluginSpec{Name: "test-cmp", Description: "A test completion source", Flavor: "completion"})
+
+	expected := map[string][]string{
+		filepath.Join("lua", "test-cmp", "init.lua"): {
+			`cmp.register_source("test_cmp", require("test-cmp.source").new())`,
+		},
+		filepath.Join("lua", "test-cmp", "source.lua"): {
+			"function source:complete(params, callback)",
+			"function source:get_trigger_characters()",
+			"function source:is_available()",
+		},
+		filepath.Join("lua", "test-cmp", "blink.lua"): {
+			"function source:get_completions(ctx, callback)",
+		},
+		filepath.Join("tests", "minimal_init.lua"): {
+			"PlenaryBustedDirectory",
+		},
+		filepath.Join("tests", "test-cmp_spec.lua"): {
+			"plugin.setup({ filetypes = true })",
+			"source:complete(",
+		},
+		filepath.Join("doc", "test-cmp.txt"): {
+			"*test-cmp-completion*",
+		},
+	}
+
+	assertFilesContain(t, pluginDir, expected)
+}
+
+func TestGenerateCompletionDeclaredOptions(t *testing.T) {
+	// source.lua reads the filetypes and trigger_characters options, which
+	// must stay defined when the user declares options of their own
+	options := []Option{{Name: "max_items", Type: "number", Default: "10"}}
+	pluginDir := generateInTempDir(t, PluginSpec{Name: "test-cmp", Flavor: "completion", Options: options})
+
+	assertFilesContain(t, pluginDir, map[string][]string{
+		filepath.Join("lua", "test-cmp", "config.lua"): {
+			`trigger_characters = { "." },`,
+			"filetypes = {},",
+			"max_items = 10,",
+		},
+	})
+}
+
+func TestGenerateGoDeclaredOptions(t *testing.T) {
+	// remote.lua reads the binary option, which must stay defined when the
+	// user declares options of their own
+	options := []Option{{Name: "timeout", Type: "number", Default: "500"}}
+	pluginDir := generateInTempDir(t, PluginSpec{Name: "test-go", Flavor: "go", Options: options})
+
+	assertFilesContain(t, pluginDir, map[string][]string{
+		filepath.Join("lua", "test-go", "config.lua"): {
+			`binary = "",`,
+			"timeout = 500,",
+		},
+	})
+
+	// A declared option of the flavor may change its default, not its type
+	options = []Option{{Name: "binary", Type: "boolean"}}
+	if err := Generate(PluginSpec{Name: "test-go-binary", Flavor: "go", Options: options}); err == nil {
+		t.Errorf("Generate should reject a go option changing the type of binary")
+	}
+}
+
+func TestGenerateGoRemotePlugin(t *testing.T) {
+	pluginDir := generateInTempDir(t, PluginSpec{Name: "test-go", Description: "A test Go plugin", Flavor: "go"})
+
+	expected := map[string][]string{
+		"go.mod": {
+			"module test-go",
+			"github.com/neovim/go-client",
+		},
+		"main.go": {
+			"nvim.New(os.Stdin, stdout, stdout, log.Printf)",
+			"v.Serve()",
+		},
+		"handlers.go": {
+			`"hello":      hello,`,
+			"v.RegisterHandler(method, fn)",
+		},
+		"Makefile": {
+			"BIN := bin/test-go",
+			"\tgo build -o $(BIN) .",
+		},
+		filepath.Join("lua", "test-go", "remote.lua"): {
+			"rpc = true,",
+			"vim.rpcrequest(M.start(), method, ...)",
+			`root .. "/bin/test-go"`,
+		},
+		filepath.Join("plugin", "test-go.lua"): {
+			`require("test-go").hello(opts.fargs)`,
+		},
+		filepath.Join("lua", "test-go", "health.lua"): {
+			`require("test-go.remote").binary()`,
+		},
+		filepath.Join("doc", "test-go.txt"): {
+			"*test-go-remote*",
+		},
+	}
+
+	assertFilesContain(t, pluginDir, expected)
+}
+
+func TestGenerateUnicodeName(t *testing.T) {
+	// Command names only take ASCII letters, so they are not derived from "café"
+	if err := Generate(PluginSpec{Name: "café"}); err == nil || !strings.Contains(err.Error(), "--command") {
+		t.Errorf("Generate should ask for a command name for café, got %v", err)
+	}
+
+	pluginDir := generateInTempDir(t, PluginSpec{Name: "café", CommandName: "Cafe", Description: "A plugin with a non-ASCII name"})
+
+	// The vimdoc header is underlined to the display width of the title
+	assertFilesContain(t, pluginDir, map[string][]string{
+		filepath.Join("doc", "café.txt"):         {"CAFÉ\n====\n"},
+		filepath.Join("lua", "café", "init.lua"): {"require(\"café.config\")"},
+		filepath.Join("plugin", "café.lua"):      {"vim.g.loaded_caf_", "nvim_create_user_command('Cafe'"},
+	})
+}
+
+func TestGenerateVimScript(t *testing.T) {
+	pluginDir := generateInTempDir(t, PluginSpec{
+		Name:        "test-vim.vim",
+		Description: "A test Vim plugin",
+		Flavor:      "vim",
+		Options:     []Option{{Name: "width", Type: "number", Default: "80", Description: "Window width"}},
+	})
+
+	expected := map[string][]string{
+		filepath.Join("plugin", "test-vim.vim"): {
+			"let g:test_vim_width = get(g:, 'test_vim_width', 80)",
+			"call test_vim#run(<q-args>)",
+		},
+		filepath.Join("autoload", "test_vim.vim"): {
+			"function! test_vim#run(args) abort",
+			"\\ 'width': g:test_vim_width,",
+		},
+		filepath.Join("lua", "test-vim", "health.lua"): {
+			`width = "number",`,
+		},
+		"README.md": {
+			"let g:test_vim_width = 80",
+		},
+		filepath.Join("doc", "test-vim.txt"): {
+			"*g:test_vim_width*",
+			"*test_vim#run()*",
+		},
+	}
+
+	assertFilesContain(t, pluginDir, expected)
+
+	// Only the files of a Vim script plugin are generated
+	if _, err := os.Stat(filepath.Join(pluginDir, "lua", "test-vim", "config.lua")); err == nil {
+		t.Errorf("The vim flavor should not generate a Lua config module")
+	}
+
+	// Options that cannot be Vim script variables are rejected
+	err := Generate(PluginSpec{
+		Name:    "test-vim-table",
+		Flavor:  "vim",
+		Options: []Option{{Name: "filetypes", Type: "table"}},
+	})
+	if err == nil {
+		t.Errorf("The vim flavor should reject table options")
+	}
+}
+
+func TestGenerateDuplicateOptions(t *testing.T) {
+	options := []Option{{Name: "a", Type: "boolean"}, {Name: "a", Type: "number"}}
+	if err := Generate(PluginSpec{Name: "test-duplicates", Options: options}); err == nil {
+		t.Errorf("Generate should reject duplicate option names")
+	}
+}
+
+func TestGenerateDeclaredOptions(t *testing.T) {
+	extra := Option{Name: "extra_flag", Type: "boolean", Default: "true", Description: "An extra option"}
+	for _, flavor := range Flavors() {
+		t.Run(flavor.Name, func(t *testing.T) {
+			pluginDir := generateInTempDir(t, PluginSpec{Name: "test-" + flavor.Name, Flavor: flavor.Name, Options: []Option{extra}})
+
+			// The options of the flavor are kept next to the declared one
+			want := []string{extra.Name + " = "}
+			for _, option := range flavor.Options {
+				want = append(want, option.Name+" = ")
+			}
+			assertFilesContain(t, pluginDir, map[string][]string{"README.md": want})
+		})
+	}
+}
+
+func TestGenerateMixed(t *testing.T) {
+	pluginDir := generateInTempDir(t, PluginSpec{Name: "test-mixed", Description: "A test mixed plugin", Flavor: "mixed"})
+
+	expected := map[string][]string{
+		filepath.Join("plugin", "test-mixed.vim"): {
+			"if !has('nvim')",
+			"call test_mixed#run(<q-args>)",
+		},
+		filepath.Join("autoload", "test_mixed.vim"): {
+			`luaeval('require("test-mixed").run(_A)', a:args)`,
+		},
+		filepath.Join("lua", "test-mixed", "init.lua"): {
+			"M.run = function(args)",
+		},
+		filepath.Join("tests", "test-mixed_spec.lua"): {
+			`vim.fn["test_mixed#run"]("test")`,
+		},
+		filepath.Join("doc", "test-mixed.txt"): {
+			"*test-mixed-vim*",
+		},
+	}
+
+	assertFilesContain(t, pluginDir, expected)
+}
+
+func TestGenerateActions(t *testing.T) {
+	pluginDir := generateInTempDir(t, PluginSpec{
+		Name:        "test-actions",
+		Description: "A test plugin with actions",
+		Actions: []Action{
+			{Name: "toggle", Keys: "<leader>tt", Description: "Toggle the panel"},
+			{Name: "open-file", Description: "Open a file"},
+		},
+	})
+
+	expected := map[string][]string{
+		filepath.Join("plugin", "test-actions.lua"): {
+			"vim.keymap.set('n', '<Plug>(test-actions-toggle)', function()",
+			"require('test-actions').open_file()",
+		},
+		filepath.Join("lua", "test-actions", "init.lua"): {
+			"if M.options.default_keymaps then",
+			`vim.keymap.set("n", "<leader>tt", "<Plug>(test-actions-toggle)", { desc = "Toggle the panel" })`,
+			"M.open_file = function()",
+		},
+		filepath.Join("lua", "test-actions", "config.lua"): {
+			"default_keymaps = true,",
+		},
+		"README.md": {
+			"| `<Plug>(test-actions-toggle)` | `<leader>tt` | Toggle the panel |",
+			"setup({ default_keymaps = false })",
+		},
+		filepath.Join("doc", "test-actions.txt"): {
+			"*<Plug>(test-actions-open-file)*",
+			"<Plug>(test-actions-toggle) (default: `<leader>tt`)",
+		},
+	}
+
+	assertFilesContain(t, pluginDir, expected)
+}
+
+func TestGenerateAutocmds(t *testing.T) {
+	pluginDir := generateInTempDir(t, PluginSpec{Name: "test-events.nvim", Description: "A test plugin with autocommands", Autocmds: true})
+
+	expected := map[string][]string{
+		filepath.Join("lua", "test-events", "autocmds.lua"): {
+			`M.group = "TestEvents"`,
+			"vim.api.nvim_create_augroup(M.group, { clear = true })",
+			`vim.api.nvim_create_autocmd("BufEnter", {`,
+			`pattern = "TestEventsReady",`,
+			`vim.api.nvim_exec_autocmds("User", { pattern = "TestEventsReady", modeline = false })`,
+		},
+		filepath.Join("lua", "test-events", "init.lua"): {
+			`local autocmds = require("test-events.autocmds")`,
+			"autocmds.ready()",
+		},
+		filepath.Join("tests", "test-events_spec.lua"): {
+			`it("fires the TestEventsReady event after setup", function()`,
+		},
+		"README.md": {
+			"`User TestEventsReady`",
+		},
+		filepath.Join("doc", "test-events.txt"): {
+			"*test-events-autocommands*",
+			"*TestEventsReady*",
+		},
+	}
+
+	assertFilesContain(t, pluginDir, expected)
+
+	// Without a Lua module there is nothing to set the autocommands up
+	if err := Generate(PluginSpec{Name: "test-vim-events", Flavor: "vim", Autocmds: true}); err == nil {
+		t.Errorf("The vim flavor should reject autocommands")
+	}
+}
+
+// assertFilesContain checks that each generated file contains the expected elements
+func TestGenerateLazySpec(t *testing.T) {
+	pluginDir := generateInTempDir(t, PluginSpec{
+		Name:     "test-lazy",
+		Actions:  []Action{{Name: "toggle", Keys: "<leader>tt", Description: "Toggle the panel"}, {Name: "open"}},
+		Autocmds: true,
+	})
+
+	expected := map[string][]string{
+		"README.md": {
+			"which loads test-lazy on first use",
+			`cmd = { "TestLazy" },`,
+			`{ "<leader>tt", desc = "Toggle the panel" },`,
+			`event = { "BufEnter" },`,
+			"opts = {",
+		},
+		filepath.Join("plugin", "test-lazy.lua"): {
+			"require('test-lazy').run(opts.args)",
+		},
+		filepath.Join("lua", "test-lazy", "init.lua"): {
+			"M.run = function(args)",
+		},
+	}
+	assertFilesContain(t, pluginDir, expected)
+
+	// The entry point only requires the Lua module inside its callbacks
+	entryPoint, err := os.ReadFile(filepath.Join(pluginDir, "plugin", "test-lazy.lua"))
+	if err != nil {
+		t.Fatalf("Failed to read the entry point: %v", err)
+	}
+	for _, line := range strings.Split(string(entryPoint), "\n") {
+		if strings.Contains(line, "require(") && !strings.HasPrefix(line, "  ") {
+			t.Errorf("The entry point requires a module at the top level: %q", line)
+		}
+	}
+
+	// Flavors load on their own triggers
+	pluginDir = generateInTempDir(t, PluginSpec{Name: "test-lazy-go", Flavor: "go"})
+	assertFilesContain(t, pluginDir, map[string][]string{
+		"README.md": {`cmd = { "TestLazyGo", "TestLazyGoLineCount" },`, `build = "make",`},
+	})
+
+	pluginDir = generateInTempDir(t, PluginSpec{Name: "test-lazy-ft", Flavor: "filetype"})
+	assertFilesContain(t, pluginDir, map[string][]string{
+		"README.md": {`ft = "test_lazy_ft",`},
+	})
+
+	pluginDir = generateInTempDir(t, PluginSpec{Name: "test-lazy-colors", Flavor: "colorscheme"})
+	content, err := os.ReadFile(filepath.Join(pluginDir, "README.md"))
+	if err != nil {
+		t.Fatalf("Failed to read README.md: %v", err)
+	}
+	if !strings.Contains(string(content), "lazy = false,") || strings.Contains(string(content), "on first use") {
+		t.Errorf("The colorscheme should be loaded at startup:\n%s", content)
+	}
+}
+
+func TestGenerateInstallSnippets(t *testing.T) {
+	pluginDir := generateInTempDir(t, PluginSpec{Name: "test-install", Owner: "me", Installs: []string{"lazy", "vim-plug", "pack"}})
+
+	assertFilesContain(t, pluginDir, map[string][]string{
+		"README.md": {
+			`"me/test-install",`,
+			"Plug 'me/test-install'",
+			`vim.pack.add({ "https://github.com/me/test-install" })`,
+			"git clone https://github.com/me/test-install ~/.local/share/nvim/site/pack/plugins/start/test-install",
+		},
+	})
+
+	content, err := os.ReadFile(filepath.Join(pluginDir, "README.md"))
+	if err != nil {
+		t.Fatalf("Failed to read README.md: %v", err)
+	}
+	for _, unselected := range []string{"packer.nvim", "mini.deps", "rocks.nvim"} {
+		if strings.Contains(string(content), unselected) {
+			t.Errorf("README.md should not document %s", unselected)
+		}
+	}
+
+	// Without an owner, the remote of an existing clone is used
+	tempDir := t.TempDir()
+	oldDir, _ := os.Getwd()
+	defer os.Chdir(oldDir)
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Failed to change to temp directory: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join("test-clone", ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	remote := "[remote \"origin\"]\n\turl = git@gitlab.com:group/test-clone.git\n"
+	if err := os.WriteFile(filepath.Join("test-clone", ".git", "config"), []byte(remote), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := Generate(PluginSpec{Name: "test-clone", Flavor: "vim"}); err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	assertFilesContain(t, filepath.Join(tempDir, "test-clone"), map[string][]string{
+		"README.md": {
+			"Plug 'https://gitlab.com/group/test-clone'",
+			"git clone https://gitlab.com/group/test-clone ~/.vim/pack/plugins/start/test-clone",
+		},
+	})
+	// Without a rockspec, the plugin cannot be installed from LuaRocks
+	if content, _ := os.ReadFile(filepath.Join(tempDir, "test-clone", "README.md")); strings.Contains(string(content), ":Rocks install") {
+		t.Errorf("README should not show rocks.nvim without a rockspec, got:\n%s", content)
+	}
+
+	if err := Generate(PluginSpec{Name: "test-dein", Installs: []string{"dein"}}); err == nil {
+		t.Errorf("Generate should reject unknown plugin managers")
+	}
+}
+
+func TestGenerateRockspec(t *testing.T) {
+	pluginDir := generateInTempDir(t, PluginSpec{
+		Name:        "test-rock",
+		Description: `A "quoted" plugin`,
+		Flavor:      "filetype",
+		Owner:       "me",
+		Author:      "Jane Doe <jane@example.com>",
+		License:     "Apache-2.0",
+		Rockspec:    true,
+	})
+
+	assertFilesContain(t, pluginDir, map[string][]string{
+		"test-rock-scm-1.rockspec": {
+			`package = "test-rock"`,
+			`url = "git+https://github.com/me/test-rock",`,
+			`summary = "A \"quoted\" plugin",`,
+			`homepage = "https://github.com/me/test-rock",`,
+			`license = "Apache-2.0",`,
+			`maintainer = "Jane Doe <jane@example.com>",`,
+			`["test-rock"] = "lua/test-rock/init.lua",`,
+			`["test-rock.config"] = "lua/test-rock/config.lua",`,
+			`["test-rock.health"] = "lua/test-rock/health.lua",`,
+			`copy_directories = { "ftdetect", "ftplugin", "queries", "after", "doc" },`,
+			`type = "busted",`,
+		},
+		".busted": {
+			`lua = "nlua",`,
+		},
+		filepath.Join(".github", "workflows", "release.yml"): {
+			"uses: nvim-neorocks/luarocks-tag-release@v7",
+			"LUAROCKS_API_KEY: ${{ secrets.LUAROCKS_API_KEY }}",
+			`summary: "A \"quoted\" plugin"`,
+		},
+		"README.md": {
+			"Apache-2.0",
+			":Rocks install test-rock",
+		},
+	})
+
+	// Vim script plugins have no Lua module to publish
+	if err := Generate(PluginSpec{Name: "test-rock-vim", Flavor: "vim", Rockspec: true}); err == nil {
+		t.Errorf("Generate should reject a rockspec for the vim flavor")
+	}
+}
+
+func TestGenerateTestFrameworks(t *testing.T) {
+	// busted runs the specs without the plenary.nvim init file
+	pluginDir := generateInTempDir(t, PluginSpec{Name: "test-busted", TestFramework: "busted"})
+	assertFilesContain(t, pluginDir, map[string][]string{
+		".busted": {`lua = "nlua",`},
+		filepath.Join("tests", "test-busted_spec.lua"): {"run them with busted, see .busted"},
+		"README.md": {"Neovim through [nlua]", "busted\n```"},
+	})
+	if _, err := os.Stat(filepath.Join(pluginDir, "tests", "minimal_init.lua")); !os.IsNotExist(err) {
+		t.Errorf("The busted framework should not generate tests/minimal_init.lua")
+	}
+
+	// none drops the test scaffold
+	pluginDir = generateInTempDir(t, PluginSpec{Name: "test-untested", TestFramework: "none", Rockspec: true})
+	for _, path := range []string{"tests", ".busted"} {
+		if _, err := os.Stat(filepath.Join(pluginDir, path)); !os.IsNotExist(err) {
+			t.Errorf("The none framework should not generate %s", path)
+		}
+	}
+	readme, err := os.ReadFile(filepath.Join(pluginDir, "README.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(readme), "The tests in `tests/`") {
+		t.Errorf("The README should not document missing tests")
+	}
+
+	if err := Generate(PluginSpec{Name: "test-jest", TestFramework: "jest"}); err == nil {
+		t.Errorf("Generate should reject unknown test frameworks")
+	}
+}
+
+func TestGenerateUserSettings(t *testing.T) {
+	tempDir := t.TempDir()
+	oldDir, _ := os.Getwd()
+	defer os.Chdir(oldDir)
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatalf("Failed to change to temp directory: %v", err)
+	}
+
+	outputDir := filepath.Join(tempDir, "src")
+	err := Generate(PluginSpec{Name: "test-settings", Author: "Jane Doe <jane@example.com>", GitHubUser: "jane", OutputDir: outputDir})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	assertFilesContain(t, filepath.Join(outputDir, "test-settings"), map[string][]string{
+		filepath.Join("lua", "test-settings", "init.lua"): {"-- Author: Jane Doe <jane@example.com>"},
+		"README.md": {`"jane/test-settings",`},
+	})
+
+	// The GitHub user does not apply to other hosts
+	err = Generate(PluginSpec{Name: "test-gitlab", GitHubUser: "jane", Host: "gitlab.com", OutputDir: outputDir})
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+	readme, err := os.ReadFile(filepath.Join(outputDir, "test-gitlab", "README.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(readme), "jane") {
+		t.Errorf("The GitHub user should not own a repository on gitlab.com")
+	}
+}
+
+func TestGenerateRejectsUnsafeNames(t *testing.T) {
+	tempDir := t.TempDir()
+	workDir := filepath.Join(tempDir, "work")
+	if err := os.Mkdir(workDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	oldDir, _ := os.Getwd()
+	defer os.Chdir(oldDir)
+	if err := os.Chdir(workDir); err != nil {
+		t.Fatalf("Failed to change to temp directory: %v", err)
+	}
+
+	if err := Generate(PluginSpec{Name: "../escaped"}); err == nil {
+		t.Errorf("Generate should reject names pointing outside of the working directory")
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, "escaped")); !os.IsNotExist(err) {
+		t.Errorf("Generate should not create anything outside of the working directory")
+	}
+}
+
+func assertFilesContain(t *testing.T, pluginDir string, expected map[string][]string) {
+	t.Helper()
+
+	for file, elements := range expected {
+		content, err := os.ReadFile(filepath.Join(pluginDir, file))
+		if err != nil {
+			t.Errorf("Failed to read %s: %v", file, err)
+			continue
+		}
+		for _, element := range elements {
+			if !strings.Contains(string(content), element) {
+				t.Errorf("%s missing expected content: %q", file, element)
+			}
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// initialCommitMessage is the message of the commit of the generated files
+const initialCommitMessage = "Initial commit"
+
+// lookGit finds the git executable, explaining what to do when it is missing
+func lookGit() (string, error) {
+	path, err := exec.LookPath("git")
+	if err != nil {
+		return "", fmt.Errorf("git is not installed or not in PATH: install git, or generate the plugin without initializing a repository")
+	}
+	return path, nil
+}
+
+// initRepository initializes a git repository in the plugin directory, adds
+// the origin remote unless there is one already, and commits every file. The
+// repository of an existing clone is reused
+func initRepository(dir, remoteURL string) error {
+	git, err := lookGit()
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, ".git")); errors.Is(err, fs.ErrNotExist) {
+		if err := runGit(git, dir, "init"); err != nil {
+			return err
+		}
+	}
+	if remoteURL != "" && runGit(git, dir, "remote", "get-url", "origin") != nil {
+		if err := runGit(git, dir, "remote", "add", "origin", remoteURL); err != nil {
+			return err
+		}
+	}
+	if err := runGit(git, dir, "add", "--all"); err != nil {
+		return err
+	}
+	return runGit(git, dir, "commit", "--message", initialCommitMessage)
+}
+
+// runGit runs a git command in a directory, reporting the output of git when
+// it fails, e.g. a missing user.name for the commit
+func runGit(git, dir string, args ...string) error {
+	cmd := exec.Command(git, args...)
+	cmd.Dir = dir
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git %s failed: %s", strings.Join(args, " "), orDefault(strings.TrimSpace(string(output)), err.Error()))
+	}
+	return nil
+}
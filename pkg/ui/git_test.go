@@ -0,0 +1,92 @@
+package ui
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerateGitignore(t *testing.T) {
+	pluginDir := generateInTempDir(t, PluginSpec{Name: "test-ignore"})
+	assertFilesContain(t, pluginDir, map[string][]string{
+		".gitignore": {"/doc/tags\n", "/.tests/\n"},
+	})
+
+	// busted installs its dependencies with luarocks, the go flavor builds into bin/
+	pluginDir = generateInTempDir(t, PluginSpec{Name: "test-ignore-go", Flavor: "go", TestFramework: "busted"})
+	assertFilesContain(t, pluginDir, map[string][]string{
+		".gitignore": {"/lua_modules/\n", "/bin/\n"},
+	})
+	if content, _ := os.ReadFile(filepath.Join(pluginDir, ".gitignore")); strings.Contains(string(content), ".tests") {
+		t.Errorf("Only plenary tests clone into .tests/, got:\n%s", content)
+	}
+
+	// The vim flavor generates help tags too, without the Lua documentation files
+	pluginDir = generateInTempDir(t, PluginSpec{Name: "test-ignore-vim", Flavor: "vim"})
+	assertFilesContain(t, pluginDir, map[string][]string{
+		".gitignore": {"/doc/tags\n"},
+	})
+}
+
+func TestGenerateGitInit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	// Commit with a fixed identity, away from the configuration of the user
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	for _, key := range []string{"GIT_AUTHOR_NAME", "GIT_COMMITTER_NAME"} {
+		t.Setenv(key, "Jane Doe")
+	}
+	for _, key := range []string{"GIT_AUTHOR_EMAIL", "GIT_COMMITTER_EMAIL"} {
+		t.Setenv(key, "jane@example.com")
+	}
+
+	pluginDir := generateInTempDir(t, PluginSpec{Name: "test-git", Owner: "me", GitInit: true})
+	git := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = pluginDir
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %s failed: %v\n%s", strings.Join(args, " "), err, output)
+		}
+		return strings.TrimSpace(string(output))
+	}
+
+	if got := git("remote", "get-url", "origin"); got != "https://github.com/me/test-git.git" {
+		t.Errorf("Expected the origin remote from the owner, got %q", got)
+	}
+	if got := git("log", "--format=%s"); got != initialCommitMessage {
+		t.Errorf("Expected a single initial commit, got %q", got)
+	}
+	if got := git("status", "--porcelain"); got != "" {
+		t.Errorf("Expected every file to be committed, got:\n%s", got)
+	}
+	if got := git("ls-files", "LICENSE", ".gitignore"); got != ".gitignore\nLICENSE" {
+		t.Errorf("Expected LICENSE and .gitignore to be committed, got %q", got)
+	}
+
+	// Without an owner, the repository has no remote
+	pluginDir = generateInTempDir(t, PluginSpec{Name: "test-git-local", GitInit: true})
+	if got := git("remote"); got != "" {
+		t.Errorf("Expected no remote without an owner, got %q", got)
+	}
+}
+
+func TestGenerateGitInitWithoutGit(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("PATH", "")
+
+	err := Generate(PluginSpec{Name: "test-no-git", OutputDir: tempDir, GitInit: true})
+	if err == nil || !strings.Contains(err.Error(), "git is not installed") {
+		t.Fatalf("Generate should report the missing git, got %v", err)
+	}
+	// Nothing is generated when git is missing
+	if _, err := os.Stat(filepath.Join(tempDir, "test-no-git")); !os.IsNotExist(err) {
+		t.Errorf("Generate should not create the plugin directory without git")
+	}
+}
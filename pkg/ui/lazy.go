@@ -0,0 +1,38 @@
+package ui
+
+import "strings"
+
+// LazyLoaded reports whether lazy.nvim can defer loading the plugin until a
+// command, default key, event or filetype triggers it
+func (d TemplateData) LazyLoaded() bool {
+	return len(d.Commands) > 0 || len(d.DefaultKeymaps()) > 0 || len(d.Events) > 0 || d.Filetype != ""
+}
+
+// LazyMain reports whether the lazy.nvim spec must name the Lua module,
+// because it is not the one lazy.nvim would guess from the repository name
+func (d TemplateData) LazyMain() bool {
+	return d.ModuleName != NormalizeName(d.RepoName).Module
+}
+
+// LazyCmd renders the user commands as the cmd field of a lazy.nvim spec
+func (d TemplateData) LazyCmd() string {
+	return luaList(d.Commands)
+}
+
+// LazyEvent renders the events as the event field of a lazy.nvim spec
+func (d TemplateData) LazyEvent() string {
+	return luaList(d.Events)
+}
+
+// luaList renders Go strings as a Lua list of string literals
+func luaList(values []string) string {
+	if len(values) == 0 {
+		return "{}"
+	}
+
+	quoted := make([]string, 0, len(values))
+	for _, value := range values {
+		quoted = append(quoted, luaString(value))
+	}
+	return "{ " + strings.Join(quoted, ", ") + " }"
+}
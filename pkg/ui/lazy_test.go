@@ -0,0 +1,52 @@
+package ui
+
+import "testing"
+
+func TestTemplateDataLazyFields(t *testing.T) {
+	data := TemplateData{
+		Commands: []string{"Demo", "DemoLineCount"},
+		Events:   []string{"InsertEnter"},
+	}
+
+	if got, want := data.LazyCmd(), `{ "Demo", "DemoLineCount" }`; got != want {
+		t.Errorf("LazyCmd() = %q, want %q", got, want)
+	}
+	if got, want := data.LazyEvent(), `{ "InsertEnter" }`; got != want {
+		t.Errorf("LazyEvent() = %q, want %q", got, want)
+	}
+	if got, want := (TemplateData{}).LazyCmd(), "{}"; got != want {
+		t.Errorf("LazyCmd() without commands = %q, want %q", got, want)
+	}
+}
+
+func TestTemplateDataLazyLoaded(t *testing.T) {
+	tests := []struct {
+		name string
+		data TemplateData
+		want bool
+	}{
+		{name: "no trigger", data: TemplateData{}, want: false},
+		{name: "command", data: TemplateData{Commands: []string{"Demo"}}, want: true},
+		{name: "action without keys", data: TemplateData{Actions: []Action{{Name: "toggle"}}}, want: false},
+		{name: "default keys", data: TemplateData{Actions: []Action{{Name: "toggle", Keys: "<leader>t"}}}, want: true},
+		{name: "event", data: TemplateData{Events: []string{"InsertEnter"}}, want: true},
+		{name: "filetype", data: TemplateData{Filetype: "demo"}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.data.LazyLoaded(); got != tt.want {
+				t.Errorf("LazyLoaded() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTemplateDataLazyMain(t *testing.T) {
+	if (TemplateData{RepoName: "demo.nvim", ModuleName: "demo"}).LazyMain() {
+		t.Errorf("LazyMain() should be false when the module is derived from the repository")
+	}
+	if !(TemplateData{RepoName: "demo.nvim", ModuleName: "other"}).LazyMain() {
+		t.Errorf("LazyMain() should be true when the module is overridden")
+	}
+}
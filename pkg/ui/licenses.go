@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"strings"
+)
+
+// defaultLicense is the SPDX identifier of the license when none is given
+const defaultLicense = "MIT"
+
+// License is a license whose text the generator writes to the LICENSE file
+type License struct {
+	ID       string // SPDX identifier, e.g. "Apache-2.0"
+	Name     string // Full name shown in the wizard
+	tmplPath string // Template of the license text, rendered with the author and year
+}
+
+// licenses lists the license texts built into the generator, the default one first
+// GPL-3.0-only and GPL-3.0-or-later share their text, only the notices differ
+var licenses = []License{
+	{ID: "MIT", Name: "MIT License", tmplPath: "templates/licenses/MIT.tmpl"},
+	{ID: "Apache-2.0", Name: "Apache License 2.0", tmplPath: "templates/licenses/Apache-2.0.tmpl"},
+	{ID: "GPL-3.0-or-later", Name: "GNU General Public License v3.0 or later", tmplPath: "templates/licenses/GPL-3.0.tmpl"},
+	{ID: "GPL-3.0-only", Name: "GNU General Public License v3.0 only", tmplPath: "templates/licenses/GPL-3.0.tmpl"},
+	{ID: "MPL-2.0", Name: "Mozilla Public License 2.0", tmplPath: "templates/licenses/MPL-2.0.tmpl"},
+	{ID: "BSD-3-Clause", Name: "BSD 3-Clause License", tmplPath: "templates/licenses/BSD-3-Clause.tmpl"},
+	{ID: "Unlicense", Name: "The Unlicense", tmplPath: "templates/licenses/Unlicense.tmpl"},
+}
+
+// Licenses returns the licenses whose text the generator can write
+func Licenses() []License {
+	return append([]License(nil), licenses...)
+}
+
+// LicenseIDs returns the SPDX identifiers of the built-in licenses
+func LicenseIDs() []string {
+	ids := make([]string, 0, len(licenses))
+	for _, license := range licenses {
+		ids = append(ids, license.ID)
+	}
+	return ids
+}
+
+// LookupLicense finds a built-in license by its SPDX identifier, ignoring
+// case. Other identifiers remain valid licenses, without a LICENSE file
+func LookupLicense(id string) (License, bool) {
+	for _, license := range licenses {
+		if strings.EqualFold(license.ID, id) {
+			return license, true
+		}
+	}
+	return License{}, false
+}
+
+// licenseFile renders the text of a license into the LICENSE file
+func licenseFile(license License) templateFile {
+	return templateFile{outputPath: "LICENSE", tmplPath: license.tmplPath}
+}
+
+// CopyrightHolder returns the name of the author for the copyright notice of
+// the LICENSE file, without the email address
+func (d TemplateData) CopyrightHolder() string {
+	name, _, _ := strings.Cut(d.Author, "<")
+	if name = strings.TrimSpace(name); name == "" {
+		return "TODO"
+	}
+	return name
+}
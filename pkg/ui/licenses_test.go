@@ -0,0 +1,97 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestLookupLicense(t *testing.T) {
+	tests := map[string]string{
+		"MIT":          "MIT",
+		"apache-2.0":   "Apache-2.0",
+		"bsd-3-clause": "BSD-3-Clause",
+		"Unlicense":    "Unlicense",
+	}
+	for id, want := range tests {
+		license, ok := LookupLicense(id)
+		if !ok || license.ID != want {
+			t.Errorf("LookupLicense(%q) = %q, %v, want %q", id, license.ID, ok, want)
+		}
+	}
+
+	for _, id := range []string{"", "GPL", "LicenseRef-Corp"} {
+		if _, ok := LookupLicense(id); ok {
+			t.Errorf("LookupLicense(%q) should find no built-in license", id)
+		}
+	}
+
+	if ids := LicenseIDs(); len(ids) != len(Licenses()) || ids[0] != defaultLicense {
+		t.Errorf("LicenseIDs() = %v, want the default license first", ids)
+	}
+
+	// Every built-in license has a text to render
+	for _, license := range Licenses() {
+		if _, err := templateFS.ReadFile(license.tmplPath); err != nil {
+			t.Errorf("License %s has no text: %v", license.ID, err)
+		}
+	}
+}
+
+func TestCopyrightHolder(t *testing.T) {
+	tests := map[string]string{
+		"Jane Doe <jane@example.com>": "Jane Doe",
+		"Jane Doe":                    "Jane Doe",
+		"<jane@example.com>":          "TODO",
+		"":                            "TODO",
+	}
+	for author, want := range tests {
+		if got := (TemplateData{Author: author}).CopyrightHolder(); got != want {
+			t.Errorf("CopyrightHolder() of %q = %q, want %q", author, got, want)
+		}
+	}
+}
+
+func TestGenerateLicense(t *testing.T) {
+	year := strconv.Itoa(time.Now().Year())
+	pluginDir := generateInTempDir(t, PluginSpec{Name: "test-mit", Author: "Jane Doe <jane@example.com>"})
+	assertFilesContain(t, pluginDir, map[string][]string{
+		"LICENSE": {
+			"MIT License",
+			"Copyright (c) " + year + " Jane Doe\n",
+		},
+		"README.md": {
+			"Distributed under the [MIT](LICENSE) license.",
+		},
+		filepath.Join("lua", "test-mit", "init.lua"): {
+			"-- License: MIT",
+		},
+	})
+
+	// Identifiers are written in their canonical case
+	pluginDir = generateInTempDir(t, PluginSpec{Name: "test-bsd", Flavor: "vim", License: "bsd-3-clause"})
+	assertFilesContain(t, pluginDir, map[string][]string{
+		"LICENSE": {
+			"Copyright (c) " + year + ", TODO\n",
+		},
+		filepath.Join("plugin", "test-bsd.vim"): {
+			`" License: BSD-3-Clause`,
+		},
+	})
+
+	// Other licenses are mentioned without a LICENSE file
+	pluginDir = generateInTempDir(t, PluginSpec{Name: "test-corp", License: "LicenseRef-Corp", Rockspec: true})
+	if _, err := os.Stat(filepath.Join(pluginDir, "LICENSE")); !os.IsNotExist(err) {
+		t.Errorf("A license without a built-in text should not get a LICENSE file")
+	}
+	assertFilesContain(t, pluginDir, map[string][]string{
+		"README.md": {
+			"## License\n\nLicenseRef-Corp",
+		},
+		"test-corp-scm-1.rockspec": {
+			`license = "LicenseRef-Corp",`,
+		},
+	})
+}
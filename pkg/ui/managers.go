@@ -0,0 +1,85 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PluginManager is a plugin manager the generated README shows how to
+// install the plugin with
+type PluginManager struct {
+	Name        string // Identifier used by templates and the --install flag
+	Description string // Name shown in the wizard
+}
+
+// pluginManagers lists the supported plugin managers, in README order
+var pluginManagers = []PluginManager{
+	{Name: "lazy", Description: "lazy.nvim"},
+	{Name: "packer", Description: "packer.nvim"},
+	{Name: "vim-plug", Description: "vim-plug"},
+	{Name: "mini.deps", Description: "mini.deps"},
+	{Name: "rocks", Description: "rocks.nvim"},
+	{Name: "pack", Description: "vim.pack and native packages"},
+}
+
+// PluginManagers returns every supported plugin manager
+func PluginManagers() []PluginManager {
+	return pluginManagers
+}
+
+// PluginManagerNames returns the names of every supported plugin manager
+func PluginManagerNames() []string {
+	names := make([]string, 0, len(pluginManagers))
+	for _, manager := range pluginManagers {
+		names = append(names, manager.Name)
+	}
+	return names
+}
+
+// ParsePluginManagers parses a comma-separated list of plugin managers
+// The managers are returned in README order, without duplicates
+func ParsePluginManagers(list string) ([]string, error) {
+	selected := map[string]bool{}
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if !isPluginManager(name) {
+			return nil, fmt.Errorf("unknown plugin manager %q: must be one of %s", name, strings.Join(PluginManagerNames(), ", "))
+		}
+		selected[name] = true
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no plugin manager given: use one or more of %s", strings.Join(PluginManagerNames(), ", "))
+	}
+
+	var names []string
+	for _, manager := range pluginManagers {
+		if selected[manager.Name] {
+			names = append(names, manager.Name)
+		}
+	}
+	return names, nil
+}
+
+// isPluginManager reports whether name is a supported plugin manager
+func isPluginManager(name string) bool {
+	for _, manager := range pluginManagers {
+		if manager.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Installs reports whether the README shows how to install the plugin with
+// the given plugin manager
+func (d TemplateData) Installs(manager string) bool {
+	for _, name := range d.PluginManagers {
+		if name == manager {
+			return true
+		}
+	}
+	return false
+}
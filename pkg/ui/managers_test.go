@@ -0,0 +1,33 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParsePluginManagers(t *testing.T) {
+	got, err := ParsePluginManagers("pack, lazy,lazy,vim-plug")
+	if err != nil {
+		t.Fatalf("ParsePluginManagers failed: %v", err)
+	}
+	if want := []string{"lazy", "vim-plug", "pack"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ParsePluginManagers() = %q, want %q", got, want)
+	}
+
+	for _, invalid := range []string{"", " , ", "lazy,dein"} {
+		if _, err := ParsePluginManagers(invalid); err == nil {
+			t.Errorf("ParsePluginManagers(%q) should have returned an error", invalid)
+		}
+	}
+}
+
+func TestTemplateDataInstalls(t *testing.T) {
+	data := TemplateData{PluginManagers: []string{"lazy", "pack"}}
+
+	if !data.Installs("lazy") || !data.Installs("pack") {
+		t.Errorf("Installs() should report the selected plugin managers")
+	}
+	if data.Installs("packer") {
+		t.Errorf("Installs() should not report unselected plugin managers")
+	}
+}
@@ -1,10 +1,15 @@
 package ui
 
 import (
+	"fmt"
+	"strings"
+
 	// bubbletea is the main framework for building terminal user interfaces
 	"github.com/charmbracelet/bubbletea"
 	// lipgloss is a styling library for terminal applications
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/vintharas/nvim-plugin/pkg/config"
 )
 
 // status represents the different screens/states of the application
@@ -12,26 +17,73 @@ type status int
 
 // Application states using iota for automatic incrementation
 const (
-	nameInput status = iota       // First screen: enter plugin name
-	descriptionInput              // Second screen: enter plugin description
-	confirmScreen                 // Third screen: confirm details
-	done                          // Final screen: display result
+	nameInput        status = iota // First screen: enter plugin name
+	namesInput                     // Edit the repository, module, identifier and command names
+	descriptionInput               // Second screen: enter plugin description
+	repositoryInput                // Enter the repository owner and host
+	managersSelect                 // Pick the plugin managers documented in the README
+	flavorSelect                   // Third screen: pick the template set
+	filetypeInput                  // Filetype flavors only: enter the filetype name
+	extensionsInput                // Filetype flavors only: enter the file extensions
+	optionsInput                   // Fourth screen: declare configuration options
+	actionsInput                   // Flavors with actions only: declare <Plug> mappings
+	autocmdsSelect                 // Flavors with a Lua module only: include autocommands
+	rockspecSelect                 // Include a luarocks rockspec and release workflow
+	licenseSelect                  // Pick the license written to the LICENSE file
+	gitSelect                      // Initialize a git repository with the generated files
+	confirmScreen                  // Fifth screen: confirm details
+	authorInput                    // Edit the author shown on the confirm screen
+	profileSelect                  // Pick a profile of the config file before the first screen
+	done                           // Final screen: display result
 )
 
 // Model represents the application state
 type Model struct {
-	status      status  // Current screen of the application
-	pluginName  string  // Stores the plugin name entered by the user
-	description string  // Stores the plugin description entered by the user
-	cursor      int     // Cursor position (not currently used but available for extension)
-	err         error   // Stores any error that occurs during plugin generation
+	status      status        // Current screen of the application
+	pluginName  string        // Stores the plugin name entered by the user
+	names       Names         // Stores the names derived from the plugin name, as edited by the user
+	derived     Names         // Stores the names derived before any edit, telling edited names apart
+	description string        // Stores the plugin description entered by the user
+	repository  string        // Stores the repository owner, optionally prefixed by its host
+	managers    []string      // Stores the plugin managers documented in the README
+	flavor      string        // Stores the name of the selected template set
+	filetype    string        // Stores the filetype entered for filetype flavors
+	extensions  string        // Stores the file extensions entered for filetype flavors
+	options     []Option      // Stores the configuration options declared by the user
+	optionInput string        // Stores the option declaration currently being typed
+	actions     []Action      // Stores the actions declared by the user
+	actionInput string        // Stores the action declaration currently being typed
+	autocmds    bool          // Stores whether to generate the autocmds module
+	rockspec    bool          // Stores whether to generate the luarocks rockspec
+	license     string        // Stores the SPDX identifier of the selected license
+	gitInit     bool          // Stores whether to initialize a git repository
+	inputErr    error         // Stores the error of the last rejected input
+	warnings    []string      // Stores the collisions of the Lua module with other modules
+	cursor      int           // Cursor position in selection lists
+	err         error         // Stores any error that occurs during plugin generation
+	config      config.Config // Stores the user settings applied to the generated plugin
 }
 
 // NewModel creates a new Model with default values
 func NewModel() Model {
-	return Model{
-		status: nameInput, // Start the application in the nameInput state
+	return NewModelWithConfig(config.Config{})
+}
+
+// NewModelWithConfig creates a new Model preselecting the defaults of the
+// user settings. When the config file defines profiles and none is active
+// yet, the wizard starts by picking one
+func NewModelWithConfig(cfg config.Config) Model {
+	m := Model{
+		status:   nameInput,            // Start the application in the nameInput state
+		managers: PluginManagerNames(), // Document every plugin manager by default
+		flavor:   cfg.Flavor,           // Preselect the default template set, if any
+		license:  cfg.License,          // Preselect the default license, if any
+		config:   cfg,
 	}
+	if len(cfg.Profiles) > 0 && cfg.Profile == "" {
+		m.status = profileSelect
+	}
+	return m
 }
 
 // Init implements bubbletea.Model
@@ -49,9 +101,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
-		case "ctrl+c", "q":
+		case "ctrl+c":
 			// Exit the application
 			return m, tea.Quit
+		case "q":
+			// Exit the application, unless "q" is typed into a text input
+			if !m.editingText() {
+				return m, tea.Quit
+			}
 		}
 	}
 
@@ -59,10 +116,38 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch m.status {
 	case nameInput:
 		return updateNameInput(msg, m)
+	case namesInput:
+		return updateNamesInput(msg, m)
 	case descriptionInput:
 		return updateDescriptionInput(msg, m)
+	case repositoryInput:
+		return updateRepositoryInput(msg, m)
+	case managersSelect:
+		return updateManagersSelect(msg, m)
+	case flavorSelect:
+		return updateFlavorSelect(msg, m)
+	case filetypeInput:
+		return updateFiletypeInput(msg, m)
+	case extensionsInput:
+		return updateExtensionsInput(msg, m)
+	case optionsInput:
+		return updateOptionsInput(msg, m)
+	case actionsInput:
+		return updateActionsInput(msg, m)
+	case autocmdsSelect:
+		return updateAutocmdsSelect(msg, m)
+	case rockspecSelect:
+		return updateRockspecSelect(msg, m)
+	case licenseSelect:
+		return updateLicenseSelect(msg, m)
+	case gitSelect:
+		return updateGitSelect(msg, m)
 	case confirmScreen:
 		return updateConfirmScreen(msg, m)
+	case authorInput:
+		return updateAuthorInput(msg, m)
+	case profileSelect:
+		return updateProfileSelect(msg, m)
 	}
 
 	return m, nil
@@ -87,36 +172,124 @@ func (m Model) View() string {
 	switch m.status {
 	case nameInput:
 		content = viewNameInput(m)
+	case namesInput:
+		content = viewNamesInput(m)
 	case descriptionInput:
 		content = viewDescriptionInput(m)
+	case repositoryInput:
+		content = viewRepositoryInput(m)
+	case managersSelect:
+		content = viewManagersSelect(m)
+	case flavorSelect:
+		content = viewFlavorSelect(m)
+	case filetypeInput:
+		content = viewFiletypeInput(m)
+	case extensionsInput:
+		content = viewExtensionsInput(m)
+	case optionsInput:
+		content = viewOptionsInput(m)
+	case actionsInput:
+		content = viewActionsInput(m)
+	case autocmdsSelect:
+		content = viewAutocmdsSelect(m)
+	case rockspecSelect:
+		content = viewRockspecSelect(m)
+	case licenseSelect:
+		content = viewLicenseSelect(m)
+	case gitSelect:
+		content = viewGitSelect(m)
 	case confirmScreen:
 		content = viewConfirmScreen(m)
+	case authorInput:
+		content = viewAuthorInput(m)
+	case profileSelect:
+		content = viewProfileSelect(m)
 	case done:
 		content = viewDone(m)
 	}
 
 	// Combine the title and content with a footer showing how to quit
-	return title + "\n" + content + "\n\nPress q to quit\n"
+	quit := "q"
+	if m.editingText() {
+		quit = "Ctrl+C"
+	}
+	return title + "\n" + content + "\n\nPress " + quit + " to quit\n"
+}
+
+// editingText reports whether the current screen is a text input, where "q"
+// is typed rather than quitting
+func (m Model) editingText() bool {
+	switch m.status {
+	case nameInput, namesInput, descriptionInput, repositoryInput, filetypeInput,
+		extensionsInput, optionsInput, actionsInput, authorInput:
+		return true
+	}
+	return false
 }
 
 // Input handlers for each screen/state
 
+// updateProfileSelect handles user input on the profile selection screen
+// The first entry keeps the settings outside of the profiles
+func updateProfileSelect(msg tea.Msg, m Model) (tea.Model, tea.Cmd) {
+	profiles := m.config.ProfileNames()
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			// Move the cursor to the previous profile
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+		case "down", "j":
+			// Move the cursor to the next profile
+			if m.cursor < len(profiles) {
+				m.cursor++
+			}
+			return m, nil
+		case "enter":
+			// Apply the profile under the cursor and move to the name screen
+			if m.cursor > 0 {
+				// The profile comes from the config file, it always exists
+				m.config, _ = m.config.WithProfile(profiles[m.cursor-1])
+				m.flavor = m.config.Flavor
+				m.license = m.config.License
+			}
+			m.cursor = 0
+			m.status = nameInput
+			return m, nil
+		}
+	}
+	return m, nil
+}
+
 // updateNameInput handles user input on the plugin name screen
 func updateNameInput(msg tea.Msg, m Model) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "enter":
-			// Move to the next screen if there's a name
-			if len(m.pluginName) > 0 {
-				m.status = descriptionInput
+			// Move to the next screen if the name is safe to generate from
+			if err := ValidateName(m.pluginName); err != nil {
+				m.inputErr = err
+				return m, nil
+			}
+			// Derive the other names again when the plugin name changed
+			if m.names.Repo != m.pluginName {
+				m.names = NormalizeName(m.pluginName)
+				m.derived = m.names
 			}
+			// Explain right away why a derived name must be edited
+			m.inputErr = m.names.Validate()
+			m.warnings = ModuleWarnings(m.names.Module)
+			m.cursor = 0
+			m.status = namesInput
 			return m, nil
 		case "backspace":
 			// Delete the last character from the plugin name
-			if len(m.pluginName) > 0 {
-				m.pluginName = m.pluginName[:len(m.pluginName)-1]
-			}
+			m.pluginName = trimLastGrapheme(m.pluginName)
 			return m, nil
 		default:
 			// Add typed characters to the plugin name
@@ -129,20 +302,117 @@ func updateNameInput(msg tea.Msg, m Model) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// updateNamesInput handles user input on the names screen
+// The arrow keys select a name, typed characters edit it
+func updateNamesInput(msg tea.Msg, m Model) (tea.Model, tea.Cmd) {
+	fields := m.names.fields()
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "shift+tab":
+			// Select the previous name
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+		case "down", "tab":
+			// Select the next name
+			if m.cursor < len(fields)-1 {
+				m.cursor++
+			}
+			return m, nil
+		case "enter":
+			// Move to the description screen if every name is valid
+			if err := m.names.Validate(); err != nil {
+				m.inputErr = err
+				return m, nil
+			}
+			m.inputErr = nil
+			m.warnings = ModuleWarnings(m.names.Module)
+			m.cursor = 0
+			m.status = descriptionInput
+			return m, nil
+		case "backspace":
+			// Delete the last character from the selected name
+			field := fields[m.cursor].value
+			*field = trimLastGrapheme(*field)
+			m.rederiveNames()
+			return m, nil
+		default:
+			// Add typed characters to the selected name
+			if msg.Type == tea.KeyRunes {
+				*fields[m.cursor].value += string(msg.Runes)
+				m.rederiveNames()
+			}
+			return m, nil
+		}
+	}
+	return m, nil
+}
+
+// rederiveNames derives the names following from an edited module again, as
+// --module does on the command line. Names the user edited are kept
+func (m *Model) rederiveNames() {
+	if m.names.Module == m.derived.Module {
+		return
+	}
+	derived := deriveNames(m.names.Repo, m.names.Module)
+	if m.names.VarName == m.derived.VarName {
+		m.names.VarName = derived.VarName
+	}
+	if m.names.Command == m.derived.Command {
+		m.names.Command = derived.Command
+	}
+	m.derived = derived
+}
+
+// nameField is a value editable on the names or author screen
+type nameField struct {
+	label string
+	value *string
+}
+
+// fields lists the names editable on the names screen, in display order
+func (n *Names) fields() []nameField {
+	return []nameField{
+		{label: "Repository", value: &n.Repo},
+		{label: "Lua module", value: &n.Module},
+		{label: "Lua identifier", value: &n.VarName},
+		{label: "Command", value: &n.Command},
+	}
+}
+
+// derivedNames returns the names edited on the names screen, or the ones
+// derived from the plugin name when the screen was skipped
+func (m Model) derivedNames() Names {
+	if m.names.Repo == "" {
+		return NormalizeName(m.pluginName)
+	}
+	return m.names
+}
+
 // updateDescriptionInput handles user input on the description screen
 func updateDescriptionInput(msg tea.Msg, m Model) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "enter":
-			// Move to the confirmation screen
-			m.status = confirmScreen
+			// Move to the repository screen, prefilled from the git remote
+			// when the plugin directory is an existing clone, or else with
+			// the GitHub user of the config file for repositories on GitHub
+			if len(m.repository) == 0 {
+				if host, owner, ok := readGitRemote(m.pluginDir()); ok {
+					m.repository = m.formatRepository(host, owner)
+				} else if m.repositoryHost() == defaultHost {
+					m.repository = m.config.GitHubUser
+				}
+			}
+			m.status = repositoryInput
 			return m, nil
 		case "backspace":
 			// Delete the last character from the description
-			if len(m.description) > 0 {
-				m.description = m.description[:len(m.description)-1]
-			}
+			m.description = trimLastGrapheme(m.description)
 			return m, nil
 		default:
 			// Add typed characters to the description
@@ -155,6 +425,447 @@ func updateDescriptionInput(msg tea.Msg, m Model) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// updateRepositoryInput handles user input on the repository screen
+func updateRepositoryInput(msg tea.Msg, m Model) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			// Move to the plugin managers screen if the repository is valid
+			// An empty repository leaves an <owner> placeholder in the README
+			if len(m.repository) > 0 {
+				if _, _, err := parseRepositoryOn(m.repository, m.repositoryHost()); err != nil {
+					m.inputErr = err
+					return m, nil
+				}
+			}
+			m.inputErr = nil
+			m.cursor = 0
+			m.status = managersSelect
+			return m, nil
+		case "backspace":
+			// Delete the last character from the repository
+			m.repository = trimLastGrapheme(m.repository)
+			return m, nil
+		default:
+			// Add typed characters to the repository
+			if msg.Type == tea.KeyRunes {
+				m.repository += string(msg.Runes)
+			}
+			return m, nil
+		}
+	}
+	return m, nil
+}
+
+// updateManagersSelect handles user input on the plugin managers screen
+func updateManagersSelect(msg tea.Msg, m Model) (tea.Model, tea.Cmd) {
+	available := PluginManagers()
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			// Move the cursor to the previous plugin manager
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+		case "down", "j":
+			// Move the cursor to the next plugin manager
+			if m.cursor < len(available)-1 {
+				m.cursor++
+			}
+			return m, nil
+		case " ", "x":
+			// Toggle the plugin manager under the cursor
+			m.managers = toggleManager(m.managers, available[m.cursor].Name)
+			return m, nil
+		case "enter":
+			// Move to the flavor selection screen once a manager is selected
+			if len(m.managers) == 0 {
+				m.inputErr = fmt.Errorf("select at least one plugin manager")
+				return m, nil
+			}
+			m.inputErr = nil
+			m.cursor = flavorIndex(m.flavor)
+			m.status = flavorSelect
+			return m, nil
+		}
+	}
+	return m, nil
+}
+
+// toggleManager adds or removes a plugin manager, keeping the README order
+func toggleManager(selected []string, name string) []string {
+	var managers []string
+	for _, manager := range PluginManagerNames() {
+		if (manager == name) != containsString(selected, manager) {
+			managers = append(managers, manager)
+		}
+	}
+	return managers
+}
+
+// containsString reports whether values contains value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// updateFlavorSelect handles user input on the flavor selection screen
+func updateFlavorSelect(msg tea.Msg, m Model) (tea.Model, tea.Cmd) {
+	available := Flavors()
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			// Move the cursor to the previous flavor
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+		case "down", "j":
+			// Move the cursor to the next flavor
+			if m.cursor < len(available)-1 {
+				m.cursor++
+			}
+			return m, nil
+		case "enter":
+			// Select the flavor under the cursor and prefill its default options
+			flavor := available[m.cursor]
+			if flavor.Name != m.flavor || len(m.options) == 0 {
+				m.options = flavorOptions(m.flavor, flavor, m.options)
+			}
+			m.flavor = flavor.Name
+			// Flavors adding a language first ask for the filetype
+			if flavor.UsesFiletype {
+				if len(m.filetype) == 0 {
+					m.filetype = strings.ToLower(m.derivedNames().VarName)
+				}
+				m.status = filetypeInput
+				return m, nil
+			}
+			m.status = optionsInput
+			return m, nil
+		}
+	}
+	return m, nil
+}
+
+// flavorOptions returns the options of a newly selected flavor: its default
+// options, followed by the options the user declared for the previous flavor
+// that the new one supports
+func flavorOptions(previous string, selected Flavor, options []Option) []Option {
+	var defaults []Option
+	if previous != "" {
+		if flavor, err := LookupFlavor(previous); err == nil {
+			defaults = flavor.Options
+		}
+	}
+
+	result := append([]Option(nil), selected.Options...)
+	for _, option := range options {
+		declared := true
+		for _, d := range defaults {
+			if option == d {
+				declared = false
+				break
+			}
+		}
+		if declared && selected.ValidateOptions(append(result, option)) == nil {
+			result = append(result, option)
+		}
+	}
+	return result
+}
+
+// updateFiletypeInput handles user input on the filetype screen
+func updateFiletypeInput(msg tea.Msg, m Model) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			// Move to the extensions screen if the filetype is valid
+			if err := ValidateFiletype(m.filetype); err != nil {
+				m.inputErr = err
+				return m, nil
+			}
+			if len(m.extensions) == 0 {
+				m.extensions = m.filetype
+			}
+			m.inputErr = nil
+			m.status = extensionsInput
+			return m, nil
+		case "backspace":
+			// Delete the last character from the filetype
+			m.filetype = trimLastGrapheme(m.filetype)
+			return m, nil
+		default:
+			// Add typed characters to the filetype
+			if msg.Type == tea.KeyRunes {
+				m.filetype += string(msg.Runes)
+			}
+			return m, nil
+		}
+	}
+	return m, nil
+}
+
+// updateExtensionsInput handles user input on the file extensions screen
+func updateExtensionsInput(msg tea.Msg, m Model) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			// Move to the options screen if the extensions are valid
+			if _, err := ParseExtensions(m.extensions); err != nil {
+				m.inputErr = err
+				return m, nil
+			}
+			m.inputErr = nil
+			m.status = optionsInput
+			return m, nil
+		case "backspace":
+			// Delete the last character from the extensions
+			m.extensions = trimLastGrapheme(m.extensions)
+			return m, nil
+		default:
+			// Add typed characters to the extensions
+			if msg.Type == tea.KeyRunes {
+				m.extensions += string(msg.Runes)
+			}
+			return m, nil
+		}
+	}
+	return m, nil
+}
+
+// updateOptionsInput handles user input on the options screen
+// Each submitted line declares one option; an empty line moves on
+func updateOptionsInput(msg tea.Msg, m Model) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			// Move on once the user submits an empty line, declaring actions
+			// first for flavors that expose them
+			if len(m.optionInput) == 0 {
+				m.inputErr = nil
+				m.status = screenAfterOptions(m.flavor)
+				if m.status == licenseSelect {
+					m.cursor = licenseIndex(m.licenseChoices(), m.license)
+				}
+				return m, nil
+			}
+			// Otherwise parse the declaration and keep it if it is valid
+			option, err := ParseOption(m.optionInput)
+			if flavor, lookupErr := LookupFlavor(m.flavor); err == nil && lookupErr == nil {
+				// The selected flavor may not support every option type
+				err = flavor.ValidateOption(option)
+			}
+			for _, declared := range m.options {
+				// Options share the defaults table, so names must stay distinct
+				if err == nil && declared.Name == option.Name {
+					err = fmt.Errorf("duplicate option %q", option.Name)
+				}
+			}
+			if err != nil {
+				m.inputErr = err
+				return m, nil
+			}
+			m.options = append(m.options, option)
+			m.optionInput = ""
+			m.inputErr = nil
+			return m, nil
+		case "backspace":
+			// Delete the last character from the option declaration
+			m.optionInput = trimLastGrapheme(m.optionInput)
+			return m, nil
+		default:
+			// Add typed characters to the option declaration
+			if msg.Type == tea.KeyRunes {
+				m.optionInput += string(msg.Runes)
+			}
+			return m, nil
+		}
+	}
+	return m, nil
+}
+
+// updateActionsInput handles user input on the actions screen
+// Each submitted line declares one action; an empty line moves on
+func updateActionsInput(msg tea.Msg, m Model) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "enter":
+			// Move on once the user submits an empty line
+			if len(m.actionInput) == 0 {
+				m.inputErr = nil
+				m.status = screenAfterActions(m.flavor)
+				if m.status == licenseSelect {
+					m.cursor = licenseIndex(m.licenseChoices(), m.license)
+				}
+				return m, nil
+			}
+			// Otherwise parse the declaration and keep it if it is valid
+			action, err := ParseAction(m.actionInput)
+			for _, declared := range m.actions {
+				// Actions share their function name, so names must stay distinct
+				if err == nil && declared.FuncName() == action.FuncName() {
+					err = fmt.Errorf("duplicate action %q", action.Name)
+				}
+			}
+			if err != nil {
+				m.inputErr = err
+				return m, nil
+			}
+			m.actions = append(m.actions, action)
+			m.actionInput = ""
+			m.inputErr = nil
+			return m, nil
+		case "backspace":
+			// Delete the last character from the action declaration
+			m.actionInput = trimLastGrapheme(m.actionInput)
+			return m, nil
+		default:
+			// Add typed characters to the action declaration
+			if msg.Type == tea.KeyRunes {
+				m.actionInput += string(msg.Runes)
+			}
+			return m, nil
+		}
+	}
+	return m, nil
+}
+
+// updateAutocmdsSelect handles user input on the autocommands screen
+func updateAutocmdsSelect(msg tea.Msg, m Model) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "y", "Y":
+			m.autocmds = true
+			m.status = rockspecSelect
+			return m, nil
+		case "n", "N", "enter":
+			m.autocmds = false
+			m.status = rockspecSelect
+			return m, nil
+		}
+	}
+	return m, nil
+}
+
+// updateRockspecSelect handles user input on the rockspec screen
+func updateRockspecSelect(msg tea.Msg, m Model) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "y", "Y":
+			m.rockspec = true
+			m.cursor = licenseIndex(m.licenseChoices(), m.license)
+			m.status = licenseSelect
+			return m, nil
+		case "n", "N", "enter":
+			m.rockspec = false
+			m.cursor = licenseIndex(m.licenseChoices(), m.license)
+			m.status = licenseSelect
+			return m, nil
+		}
+	}
+	return m, nil
+}
+
+// updateLicenseSelect handles user input on the license selection screen
+func updateLicenseSelect(msg tea.Msg, m Model) (tea.Model, tea.Cmd) {
+	choices := m.licenseChoices()
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			// Move the cursor to the previous license
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+		case "down", "j":
+			// Move the cursor to the next license
+			if m.cursor < len(choices)-1 {
+				m.cursor++
+			}
+			return m, nil
+		case "enter":
+			// Select the license under the cursor and move to the git screen
+			m.license = choices[m.cursor].ID
+			m.cursor = 0
+			m.status = gitSelect
+			return m, nil
+		}
+	}
+	return m, nil
+}
+
+// updateGitSelect handles user input on the git screen
+func updateGitSelect(msg tea.Msg, m Model) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "y", "Y":
+			// Explain right away that git is missing rather than after generating
+			if _, err := lookGit(); err != nil {
+				m.inputErr = err
+				return m, nil
+			}
+			m.inputErr = nil
+			m.gitInit = true
+			m.status = confirmScreen
+			return m, nil
+		case "n", "N", "enter":
+			m.inputErr = nil
+			m.gitInit = false
+			m.status = confirmScreen
+			return m, nil
+		}
+	}
+	return m, nil
+}
+
+// licenseChoices lists the built-in licenses, followed by the license of the
+// settings when it has no built-in text, e.g. a company license
+func (m Model) licenseChoices() []License {
+	choices := Licenses()
+	if _, ok := LookupLicense(m.license); !ok && m.license != "" {
+		choices = append(choices, License{ID: m.license, Name: "no LICENSE file"})
+	}
+	return choices
+}
+
+// screenAfterOptions returns the screen following the options screen,
+// skipping the screens the flavor has no use for
+func screenAfterOptions(flavorName string) status {
+	if flavor, err := LookupFlavor(flavorName); err == nil && flavor.UsesActions {
+		return actionsInput
+	}
+	return screenAfterActions(flavorName)
+}
+
+// screenAfterActions returns the screen following the actions screen
+// Flavors without a Lua module have no autocommands and no rockspec
+func screenAfterActions(flavorName string) status {
+	if flavor, err := LookupFlavor(flavorName); err == nil && !flavor.HasLuaModule() {
+		return licenseSelect
+	}
+	return autocmdsSelect
+}
+
 // updateConfirmScreen handles user input on the confirmation screen
 func updateConfirmScreen(msg tea.Msg, m Model) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
@@ -162,7 +873,7 @@ func updateConfirmScreen(msg tea.Msg, m Model) (tea.Model, tea.Cmd) {
 		switch msg.String() {
 		case "y", "Y":
 			// If the user confirms, generate the plugin
-			err := GeneratePlugin(m.pluginName, m.description)
+			err := Generate(m.spec())
 			if err != nil {
 				m.err = err
 			}
@@ -173,20 +884,165 @@ func updateConfirmScreen(msg tea.Msg, m Model) (tea.Model, tea.Cmd) {
 			// If the user declines, go back to the first screen
 			m.status = nameInput
 			return m, nil
+		case "e", "E":
+			// Edit the author inferred from the settings or git
+			m.cursor = 0
+			m.status = authorInput
+			return m, nil
+		}
+	}
+	return m, nil
+}
+
+// updateAuthorInput handles user input on the author screen
+// The arrow keys select the name or email, typed characters edit it
+func updateAuthorInput(msg tea.Msg, m Model) (tea.Model, tea.Cmd) {
+	fields := m.authorFields()
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "shift+tab":
+			// Select the name
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+		case "down", "tab":
+			// Select the email
+			if m.cursor < len(fields)-1 {
+				m.cursor++
+			}
+			return m, nil
+		case "enter":
+			// Go back to the confirm screen with the edited author
+			m.config.AuthorName = strings.TrimSpace(m.config.AuthorName)
+			m.config.AuthorEmail = strings.TrimSpace(m.config.AuthorEmail)
+			m.cursor = 0
+			m.status = confirmScreen
+			return m, nil
+		case "backspace":
+			// Delete the last character from the selected field
+			field := fields[m.cursor].value
+			*field = trimLastGrapheme(*field)
+			return m, nil
+		default:
+			// Add typed characters to the selected field
+			if msg.Type == tea.KeyRunes {
+				*fields[m.cursor].value += string(msg.Runes)
+			}
+			return m, nil
 		}
 	}
 	return m, nil
 }
 
+// authorFields lists the parts of the author editable on the author screen
+func (m *Model) authorFields() []nameField {
+	return []nameField{
+		{label: "Name", value: &m.config.AuthorName},
+		{label: "Email", value: &m.config.AuthorEmail},
+	}
+}
+
+// spec builds the plugin spec from the values collected by the wizard
+func (m Model) spec() PluginSpec {
+	spec := PluginSpec{
+		Name:          m.pluginName,
+		RepoName:      m.names.Repo,
+		ModuleName:    m.names.Module,
+		VarName:       m.names.VarName,
+		CommandName:   m.names.Command,
+		Description:   m.description,
+		Options:       m.options,
+		Flavor:        m.flavor,
+		Installs:      m.managers,
+		Rockspec:      m.rockspec,
+		Author:        m.config.Author(),
+		License:       m.license,
+		OutputDir:     config.ExpandPath(m.config.OutputDir),
+		TestFramework: m.config.TestFramework,
+		GitInit:       m.gitInit,
+	}
+
+	// The repository was validated when leaving its screen
+	spec.Host = m.config.Host
+	if len(m.repository) > 0 {
+		spec.Host, spec.Owner, _ = parseRepositoryOn(m.repository, m.repositoryHost())
+	}
+
+	flavor, err := LookupFlavor(m.flavor)
+	if err != nil {
+		return spec
+	}
+
+	// Only flavors adding a language use the filetype screens
+	if flavor.UsesFiletype {
+		spec.Filetype = m.filetype
+		// The extensions were validated when leaving their screen
+		spec.Extensions, _ = ParseExtensions(m.extensions)
+	}
+
+	// Actions declared for another flavor are left out
+	if flavor.UsesActions {
+		spec.Actions = m.actions
+	}
+	spec.Autocmds = m.autocmds && flavor.HasLuaModule()
+	spec.Rockspec = m.rockspec && flavor.HasLuaModule()
+
+	return spec
+}
+
 // View helpers - functions to render each screen
 
+// noProfile labels the settings outside of the profiles
+const noProfile = "(none)"
+
+// viewProfileSelect renders the profile selection screen
+func viewProfileSelect(m Model) string {
+	list := ""
+	for i, name := range append([]string{noProfile}, m.config.ProfileNames()...) {
+		// Highlight the profile under the cursor
+		line := "  " + name
+		if i == m.cursor {
+			line = lipgloss.NewStyle().Foreground(lipgloss.Color("#7D56F4")).Bold(true).Render("> " + name)
+		}
+		list += line + "\n"
+	}
+
+	return lipgloss.NewStyle().MarginBottom(1).Render("Profile:") + "\n" +
+		list + "\n" +
+		"Use ↑/↓ to choose the profile of the config file applied to the plugin and press Enter"
+}
+
 // viewNameInput renders the plugin name input screen
 func viewNameInput(m Model) string {
 	return lipgloss.NewStyle().MarginBottom(1).Render("Plugin Name:") + "\n" +
 		m.pluginName + "█" + "\n\n" + // "█" represents the cursor
+		viewInputError(m) +
+		viewNames(m.pluginName) +
 		"Enter the name of your Neovim plugin and press Enter"
 }
 
+// viewNames previews the identifiers derived from a valid plugin name
+func viewNames(name string) string {
+	if ValidateName(name) != nil {
+		return ""
+	}
+	names := NormalizeName(name)
+	return lipgloss.NewStyle().Foreground(lipgloss.Color("#888888")).Render(
+		"Module: "+names.Module+"  Lua identifier: "+names.VarName+"  Command: "+names.Command) + "\n\n"
+}
+
+// viewNamesInput renders the names screen
+func viewNamesInput(m Model) string {
+	return lipgloss.NewStyle().MarginBottom(1).Render("Plugin Names:") + "\n" +
+		viewFields(m.names.fields(), m.cursor) + "\n" +
+		viewInputError(m) +
+		viewWarnings(m) +
+		"Use ↑/↓ to select a name derived from " + m.pluginName + ", type to edit it, and press Enter"
+}
+
 // viewDescriptionInput renders the description input screen
 func viewDescriptionInput(m Model) string {
 	return lipgloss.NewStyle().MarginBottom(1).Render("Plugin Description:") + "\n" +
@@ -194,15 +1050,332 @@ func viewDescriptionInput(m Model) string {
 		"Enter a short description and press Enter"
 }
 
+// viewRepositoryInput renders the repository input screen
+func viewRepositoryInput(m Model) string {
+	return lipgloss.NewStyle().MarginBottom(1).Render("Repository Owner:") + "\n" +
+		m.repository + "█" + "\n\n" + // "█" represents the cursor
+		viewInputError(m) +
+		"Enter the user or organization owning " + m.derivedNames().Repo + " on " + m.repositoryHost() + ", prefixed by the host\n" +
+		"on other forges (e.g. gitlab.com/group), or leave it empty, and press Enter"
+}
+
+// viewManagersSelect renders the plugin managers selection screen
+func viewManagersSelect(m Model) string {
+	list := ""
+	for i, manager := range PluginManagers() {
+		check := "[ ] "
+		if containsString(m.managers, manager.Name) {
+			check = "[x] "
+		}
+		// Highlight the plugin manager under the cursor
+		line := "  " + check + manager.Description
+		if i == m.cursor {
+			line = lipgloss.NewStyle().Foreground(lipgloss.Color("#7D56F4")).Bold(true).
+				Render("> " + check + manager.Description)
+		}
+		list += line + "\n"
+	}
+
+	return lipgloss.NewStyle().MarginBottom(1).Render("Plugin Managers:") + "\n" +
+		list + "\n" +
+		viewInputError(m) +
+		"Use ↑/↓ and Space to choose the installation instructions of the README, then press Enter"
+}
+
+// viewFlavorSelect renders the flavor selection screen
+func viewFlavorSelect(m Model) string {
+	list := ""
+	for i, flavor := range Flavors() {
+		// Highlight the flavor under the cursor
+		line := "  " + flavor.Name + " - " + flavor.Description
+		if i == m.cursor {
+			line = lipgloss.NewStyle().Foreground(lipgloss.Color("#7D56F4")).Bold(true).
+				Render("> " + flavor.Name + " - " + flavor.Description)
+		}
+		list += line + "\n"
+	}
+
+	return lipgloss.NewStyle().MarginBottom(1).Render("Plugin Flavor:") + "\n" +
+		list + "\n" +
+		"Use ↑/↓ to choose the kind of plugin and press Enter"
+}
+
+// viewFiletypeInput renders the filetype input screen
+func viewFiletypeInput(m Model) string {
+	return lipgloss.NewStyle().MarginBottom(1).Render("Filetype:") + "\n" +
+		m.filetype + "█" + "\n\n" + // "█" represents the cursor
+		viewInputError(m) +
+		"Enter the name of the filetype (e.g. mylang) and press Enter"
+}
+
+// viewExtensionsInput renders the file extensions input screen
+func viewExtensionsInput(m Model) string {
+	return lipgloss.NewStyle().MarginBottom(1).Render("File Extensions:") + "\n" +
+		m.extensions + "█" + "\n\n" + // "█" represents the cursor
+		viewInputError(m) +
+		"Enter the extensions detected as " + m.filetype + ", separated by commas, and press Enter"
+}
+
+// viewInputError renders why the last input was rejected, if it was
+func viewInputError(m Model) string {
+	if m.inputErr == nil {
+		return ""
+	}
+	return lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FF0000")).
+		Render(m.inputErr.Error()) + "\n\n"
+}
+
+// viewWarnings renders the collisions of the Lua module, if there are any
+func viewWarnings(m Model) string {
+	if len(m.warnings) == 0 {
+		return ""
+	}
+	warnings := ""
+	for _, warning := range m.warnings {
+		warnings += "Warning: " + warning + "\n"
+	}
+	return lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#FFA500")).
+		Render(strings.TrimSuffix(warnings, "\n")) + "\n\n"
+}
+
+// viewOptionsInput renders the options declaration screen
+func viewOptionsInput(m Model) string {
+	content := lipgloss.NewStyle().MarginBottom(1).Render("Plugin Options:") + "\n" +
+		formatOptions(m.options) + "\n" +
+		m.optionInput + "█" + "\n\n" // "█" represents the cursor
+
+	return content + viewInputError(m) +
+		"Declare an option as name:type[:default[:description]] and press Enter\n" +
+		"Types: boolean, number, string, table, function\n" +
+		"Press Enter on an empty line to continue"
+}
+
+// viewActionsInput renders the actions declaration screen
+func viewActionsInput(m Model) string {
+	content := lipgloss.NewStyle().MarginBottom(1).Render("Plugin Actions:") + "\n" +
+		formatActions(m.derivedNames().Module, m.actions) + "\n" +
+		m.actionInput + "█" + "\n\n" // "█" represents the cursor
+
+	return content + viewInputError(m) +
+		"Declare an action as name[:keys[:description]] and press Enter\n" +
+		"Each action becomes a <Plug>(" + m.derivedNames().Module + "-name) mapping, keys are mapped by default\n" +
+		"Press Enter on an empty line to continue"
+}
+
+// viewAutocmdsSelect renders the autocommands screen
+func viewAutocmdsSelect(m Model) string {
+	return lipgloss.NewStyle().MarginBottom(1).Render("Autocommands:") + "\n" +
+		"Include an autocmds.lua module with a " + pascalCase(m.derivedNames().Module) + " augroup\n" +
+		"and a User " + pascalCase(m.derivedNames().Module) + "Ready event fired after setup()? (y/N)"
+}
+
+// viewRockspecSelect renders the rockspec screen
+func viewRockspecSelect(m Model) string {
+	return lipgloss.NewStyle().MarginBottom(1).Render("LuaRocks:") + "\n" +
+		"Include a " + m.derivedNames().Repo + "-scm-1.rockspec and a workflow publishing it to LuaRocks\n" +
+		"on each version tag, for rocks.nvim users? (y/N)"
+}
+
+// viewLicenseSelect renders the license selection screen
+func viewLicenseSelect(m Model) string {
+	list := ""
+	for i, license := range m.licenseChoices() {
+		// Highlight the license under the cursor
+		line := "  " + license.ID + " - " + license.Name
+		if i == m.cursor {
+			line = lipgloss.NewStyle().Foreground(lipgloss.Color("#7D56F4")).Bold(true).
+				Render("> " + license.ID + " - " + license.Name)
+		}
+		list += line + "\n"
+	}
+
+	return lipgloss.NewStyle().MarginBottom(1).Render("License:") + "\n" +
+		list + "\n" +
+		"Use ↑/↓ to choose the license written to LICENSE and mentioned in the README and headers, and press Enter"
+}
+
+// viewGitSelect renders the git screen
+func viewGitSelect(m Model) string {
+	return lipgloss.NewStyle().MarginBottom(1).Render("Git Repository:") + "\n" +
+		viewInputError(m) +
+		"Initialize a git repository and commit the generated files, adding\n" +
+		m.repositoryURL(m.derivedNames().Repo) + ".git as the origin remote when the owner is known? (y/N)"
+}
+
 // viewConfirmScreen renders the confirmation screen
 func viewConfirmScreen(m Model) string {
-	summary := "Plugin Name: " + m.pluginName + "\n" +
-		"Description: " + m.description + "\n\n" +
-		"Is this correct? (y/n)"
+	names := m.derivedNames()
+	summary := ""
+	// Make it obvious which profile provides the settings below
+	if len(m.config.Profiles) > 0 || m.config.Profile != "" {
+		summary += "Profile: " + orDefault(m.config.Profile, noProfile) + "\n"
+	}
+	summary += "Plugin Name: " + m.pluginName + "\n" +
+		"Lua Module: " + names.Module + "\n" +
+		"Lua Identifier: " + names.VarName + "\n" +
+		"Command: :" + names.Command + "\n" +
+		"Description: " + m.description + "\n" +
+		"Repository: " + m.repositoryURL(names.Repo) + "\n" +
+		"Directory: " + m.pluginDir() + "\n" +
+		"Author: " + orDefault(m.config.Author(), "TODO") + "\n" +
+		"License: " + orDefault(m.license, defaultLicense) + "\n" +
+		"Plugin Managers: " + strings.Join(m.managers, ", ") + "\n" +
+		"Flavor: " + flavorName(m.flavor) + "\n"
+
+	// Show the language details for flavors adding a filetype
+	if flavor, err := LookupFlavor(m.flavor); err == nil && flavor.UsesFiletype {
+		summary += "Filetype: " + m.filetype + "\n" +
+			"Extensions: " + m.extensions + "\n"
+	}
+
+	summary += "Options:\n" + formatOptions(m.options) + "\n"
+
+	// Show the actions for flavors exposing them
+	if flavor, err := LookupFlavor(m.flavor); err == nil && flavor.UsesActions {
+		summary += "Actions:\n" + formatActions(names.Module, m.actions) + "\n"
+	}
+
+	// Show the autocommands, test framework and rockspec choices for flavors with a Lua module
+	if flavor, err := LookupFlavor(m.flavor); err == nil && flavor.HasLuaModule() {
+		summary += "Autocommands: " + yesNo(m.autocmds) + "\n" +
+			"Tests: " + orDefault(m.config.TestFramework, testFrameworkPlenary) + "\n" +
+			"Rockspec: " + yesNo(m.rockspec) + "\n"
+	}
+	summary += "Git Repository: " + yesNo(m.gitInit) + "\n\n"
+
+	summary += viewWarnings(m) + "Is this correct? (y/n, e to edit the author)"
 
 	return lipgloss.NewStyle().MarginBottom(1).Render("Confirm Details:") + "\n" + summary
 }
 
+// viewAuthorInput renders the author screen
+func viewAuthorInput(m Model) string {
+	return lipgloss.NewStyle().MarginBottom(1).Render("Plugin Author:") + "\n" +
+		viewFields(m.authorFields(), m.cursor) + "\n" +
+		"Use ↑/↓ to select the name or email, type to edit it, and press Enter to confirm"
+}
+
+// viewFields renders a list of editable fields with the cursor on the selected one
+func viewFields(fields []nameField, cursor int) string {
+	list := ""
+	for i, field := range fields {
+		line := fmt.Sprintf("  %-15s %s", field.label+":", *field.value)
+		if i == cursor {
+			line = lipgloss.NewStyle().Foreground(lipgloss.Color("#7D56F4")).Bold(true).
+				Render(fmt.Sprintf("> %-15s %s█", field.label+":", *field.value))
+		}
+		list += line + "\n"
+	}
+	return list
+}
+
+// pluginDir returns the directory the plugin is generated in
+func (m Model) pluginDir() string {
+	return PluginDir(config.ExpandPath(m.config.OutputDir), m.derivedNames().Repo)
+}
+
+// flavorIndex returns the position of a flavor in the selection list, the
+// default flavor when it is not found
+func flavorIndex(name string) int {
+	for i, flavor := range Flavors() {
+		if flavor.Name == name {
+			return i
+		}
+	}
+	return 0
+}
+
+// licenseIndex returns the position of a license among the choices, the
+// default license when none is selected
+func licenseIndex(choices []License, id string) int {
+	for i, license := range choices {
+		if strings.EqualFold(license.ID, orDefault(id, defaultLicense)) {
+			return i
+		}
+	}
+	return 0
+}
+
+// flavorName returns the name of the selected flavor, resolving the default
+func flavorName(name string) string {
+	if name == "" {
+		return defaultFlavor
+	}
+	return name
+}
+
+// repositoryHost returns the host of repositories entered without one, the
+// host of the settings or github.com
+func (m Model) repositoryHost() string {
+	return orDefault(m.config.Host, defaultHost)
+}
+
+// formatRepository renders a repository as entered on the repository screen
+func (m Model) formatRepository(host, owner string) string {
+	if host == m.repositoryHost() {
+		return owner
+	}
+	return host + "/" + owner
+}
+
+// repositoryURL renders the URL of the plugin repository
+func (m Model) repositoryURL(repoName string) string {
+	host, owner, err := parseRepositoryOn(m.repository, m.repositoryHost())
+	if err != nil {
+		host, owner = m.repositoryHost(), ownerPlaceholder
+	}
+	return TemplateData{RepoName: repoName, Host: host, Owner: owner}.RepoURL()
+}
+
+// formatOptions renders the declared options as an indented list
+func formatOptions(options []Option) string {
+	if len(options) == 0 {
+		return "  (none)\n"
+	}
+
+	var list string
+	for _, option := range options {
+		list += "  • " + option.Name + " (" + option.Type + ") = " + option.LuaDefault() +
+			" - " + option.Description + "\n"
+	}
+	return list
+}
+
+// orDefault renders a setting, or the default used when it is not set
+func orDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+// yesNo renders a boolean choice
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+// formatActions renders the declared actions as an indented list
+func formatActions(moduleName string, actions []Action) string {
+	if len(actions) == 0 {
+		return "  (none)\n"
+	}
+
+	var list string
+	for _, action := range actions {
+		list += "  • <Plug>(" + moduleName + "-" + action.Name + ")"
+		if action.Keys != "" {
+			list += " on " + action.Keys
+		}
+		list += " - " + action.Description + "\n"
+	}
+	return list
+}
+
 // viewDone renders the final screen showing success or error
 func viewDone(m Model) string {
 	// If there was an error, show it in red
@@ -220,5 +1393,5 @@ func viewDone(m Model) string {
 		Bold(true).
 		Render("✓ Plugin created successfully!") + "\n\n" +
 		"Your new plugin has been created at:\n" +
-		"./" + m.pluginName
-}
\ No newline at end of file
+		m.pluginDir()
+}
@@ -1,10 +1,14 @@
 package ui
 
 import (
+	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/vintharas/nvim-plugin/pkg/config"
 )
 
 // Helper function to simulate key presses
@@ -77,8 +81,202 @@ func TestModelUpdateNameInput(t *testing.T) {
 	m = pressKeys(updatedModel, "enter")
 	updatedModel = m.(Model)
 
+	if updatedModel.status != namesInput {
+		t.Errorf("After Enter, expected to move to namesInput state, got %v", updatedModel.status)
+	}
+	if updatedModel.names.Module != "tes" || updatedModel.names.Command != "Tes" {
+		t.Errorf("After Enter, expected the names derived from 'tes', got %+v", updatedModel.names)
+	}
+}
+
+func TestModelQuitKey(t *testing.T) {
+	// "q" is typed into text inputs
+	m := pressKeys(NewModel(), "q", "uick")
+	if name := m.(Model).pluginName; name != "quick" {
+		t.Errorf("Expected plugin name to be 'quick', got %q", name)
+	}
+	m = pressKeys(Model{status: optionsInput}, "q", ":boolean")
+	if input := m.(Model).optionInput; input != "q:boolean" {
+		t.Errorf("Expected option declaration to be 'q:boolean', got %q", input)
+	}
+	if view := m.View(); !strings.Contains(view, "Press Ctrl+C to quit") {
+		t.Errorf("Text inputs should show Ctrl+C to quit, got:\n%s", view)
+	}
+
+	// "q" quits the other screens, Ctrl+C quits every screen
+	quits := []struct {
+		status status
+		key    tea.KeyMsg
+	}{
+		{flavorSelect, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")}},
+		{flavorSelect, tea.KeyMsg{Type: tea.KeyCtrlC}},
+		{nameInput, tea.KeyMsg{Type: tea.KeyCtrlC}},
+	}
+	for _, quit := range quits {
+		_, cmd := Model{status: quit.status}.Update(quit.key)
+		if cmd == nil {
+			t.Errorf("%q should quit on screen %v", quit.key.String(), quit.status)
+		} else if _, ok := cmd().(tea.QuitMsg); !ok {
+			t.Errorf("%q should quit on screen %v", quit.key.String(), quit.status)
+		}
+	}
+}
+
+func TestModelUpdateNameInputDeletesGraphemes(t *testing.T) {
+	m := pressKeys(NewModel(), "caf", "e\u0301", "backspace")
+	if name := m.(Model).pluginName; name != "caf" {
+		t.Errorf("Backspace should delete the accented letter, got %q", name)
+	}
+
+	m = pressKeys(NewModel(), "日本", "backspace")
+	if name := m.(Model).pluginName; name != "日" {
+		t.Errorf("Backspace should delete a whole character, got %q", name)
+	}
+}
+
+func TestModelUpdateNamesInput(t *testing.T) {
+	m := pressKeys(NewModel(), "foo.nvim", "enter")
+	updatedModel := m.(Model)
+
+	want := Names{Repo: "foo.nvim", Module: "foo", VarName: "foo", Command: "Foo"}
+	if updatedModel.names != want {
+		t.Fatalf("Expected the names derived from foo.nvim, got %+v", updatedModel.names)
+	}
+
+	// Edit the command name
+	m = pressKeys(updatedModel, "down", "down", "down", "backspace", "backspace", "backspace", "Bar")
+	updatedModel = m.(Model)
+	if updatedModel.names.Command != "Bar" {
+		t.Errorf("Expected the command to be edited to 'Bar', got %q", updatedModel.names.Command)
+	}
+	if !strings.Contains(updatedModel.View(), "> Command:") {
+		t.Errorf("Names screen should select the command, got:\n%s", updatedModel.View())
+	}
+
+	// An invalid module name is rejected
+	m = pressKeys(updatedModel, "up", "up", "-")
+	updatedModel = m.(Model)
+	if updatedModel.names.Module != "foo-" {
+		t.Fatalf("Expected the module to be edited to 'foo-', got %q", updatedModel.names.Module)
+	}
+	// The Lua identifier follows the module, the edited command does not
+	if updatedModel.names.VarName != "foo_" || updatedModel.names.Command != "Bar" {
+		t.Errorf("Expected the foo_ identifier and the Bar command, got %+v", updatedModel.names)
+	}
+	m = pressKeys(updatedModel, "up", "up", "up", "down", "backspace", "backspace", "backspace", "backspace", "1foo", "enter")
+	updatedModel = m.(Model)
+	if updatedModel.status != namesInput || updatedModel.inputErr == nil {
+		t.Errorf("Module %q should be rejected, got status %v", updatedModel.names.Module, updatedModel.status)
+	}
+
+	// Valid names move on and are passed to the generator
+	m = pressKeys(updatedModel, "backspace", "backspace", "backspace", "backspace", "bar", "enter")
+	updatedModel = m.(Model)
+	if updatedModel.status != descriptionInput {
+		t.Fatalf("After Enter, expected to move to descriptionInput state, got %v", updatedModel.status)
+	}
+	spec := updatedModel.spec()
+	if spec.ModuleName != "bar" || spec.VarName != "bar" || spec.CommandName != "Bar" || spec.RepoName != "foo.nvim" {
+		t.Errorf("Expected the edited names in the spec, got %+v", spec)
+	}
+}
+
+func TestModelUpdateNamesInputRederives(t *testing.T) {
+	// Editing the module gives the names the CLI derives from --module
+	m := pressKeys(NewModel(), "foo.nvim", "enter", "down", "_core")
+	updatedModel := m.(Model)
+
+	want, _ := PluginSpec{Name: "foo.nvim", ModuleName: "foo_core"}.Names()
+	if updatedModel.names != want {
+		t.Errorf("Expected the names derived from the foo_core module %+v, got %+v", want, updatedModel.names)
+	}
+
+	// An edited Lua identifier is kept when the module changes again
+	m = pressKeys(updatedModel, "down", "backspace", "backspace", "backspace", "backspace", "backspace", "up", "backspace")
+	updatedModel = m.(Model)
+	if updatedModel.names.VarName != "foo" || updatedModel.names.Command != "FooCor" {
+		t.Errorf("Expected the foo identifier and the FooCor command, got %+v", updatedModel.names)
+	}
+}
+
+func TestModelUpdateNamesInputRejectsBuiltinCommands(t *testing.T) {
+	m := pressKeys(NewModel(), "man.nvim", "enter", "enter")
+	updatedModel := m.(Model)
+
+	if updatedModel.status != namesInput || !strings.Contains(updatedModel.View(), "built-in :Man command") {
+		t.Fatalf("The Man command should be rejected, got status %v and view:\n%s", updatedModel.status, updatedModel.View())
+	}
+
+	// Renaming the command lets the wizard move on
+	m = pressKeys(updatedModel, "down", "down", "down", "Pages", "enter")
+	if updatedModel := m.(Model); updatedModel.status != descriptionInput || updatedModel.names.Command != "ManPages" {
+		t.Errorf("Expected the ManPages command to be accepted, got status %v and %+v", updatedModel.status, updatedModel.names)
+	}
+}
+
+func TestModelUpdateNamesInputNonASCIICommand(t *testing.T) {
+	m := pressKeys(NewModel(), "café", "enter", "enter")
+	updatedModel := m.(Model)
+
+	if updatedModel.status != namesInput || !strings.Contains(updatedModel.View(), "only ASCII letters and digits") {
+		t.Fatalf("The Café command should be rejected, got status %v and view:\n%s", updatedModel.status, updatedModel.View())
+	}
+
+	// Entering an ASCII command lets the wizard move on
+	m = pressKeys(updatedModel, "down", "down", "down", "backspace", "e", "enter")
+	if updatedModel := m.(Model); updatedModel.status != descriptionInput || updatedModel.names.Command != "Cafe" {
+		t.Errorf("Expected the Cafe command to be accepted, got status %v and %+v", updatedModel.status, updatedModel.names)
+	}
+}
+
+func TestModelNamesWarnings(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+
+	// A Lua keyword is explained as soon as the names screen opens
+	m := pressKeys(NewModel(), "end.nvim", "enter")
+	if updatedModel := m.(Model); updatedModel.status != namesInput || !strings.Contains(updatedModel.View(), "is a Lua keyword") {
+		t.Errorf("The end module should be rejected on the names screen, got:\n%s", updatedModel.View())
+	}
+
+	// A common module is only a warning, repeated on the confirm screen
+	m = pressKeys(NewModel(), "telescope", "enter")
+	if view := m.View(); !strings.Contains(view, "Warning: the Lua module \"telescope\" is a common module") {
+		t.Errorf("The names screen should warn about the telescope module, got:\n%s", view)
+	}
+	m = pressKeys(m, "enter")
+	updatedModel := m.(Model)
 	if updatedModel.status != descriptionInput {
-		t.Errorf("After Enter, expected to move to descriptionInput state, got %v", updatedModel.status)
+		t.Fatalf("A warning should not block the wizard, got status %v", updatedModel.status)
+	}
+	updatedModel.status = confirmScreen
+	if view := updatedModel.View(); !strings.Contains(view, "Warning: the Lua module \"telescope\"") {
+		t.Errorf("The confirm screen should repeat the warning, got:\n%s", view)
+	}
+}
+
+func TestModelUpdateNameInputRejectsUnsafeNames(t *testing.T) {
+	for _, name := range []string{"../evil", "my plugin", "a/b"} {
+		m := pressKeys(NewModel(), name, "enter")
+		updatedModel := m.(Model)
+
+		if updatedModel.status != nameInput || updatedModel.inputErr == nil {
+			t.Errorf("Name %q should be rejected, got status %v", name, updatedModel.status)
+		}
+		if !strings.Contains(updatedModel.View(), "invalid plugin name") {
+			t.Errorf("Name screen should explain why %q is rejected", name)
+		}
+	}
+
+	// Enter on an empty name explains that it is required
+	m := pressKeys(NewModel(), "enter")
+	if updatedModel := m.(Model); updatedModel.status != nameInput || updatedModel.inputErr == nil {
+		t.Errorf("An empty name should be rejected, got status %v", updatedModel.status)
+	}
+
+	// A valid name previews the identifiers derived from it
+	m = pressKeys(NewModel(), "foo.nvim")
+	if view := m.View(); !strings.Contains(view, "Module: foo ") || !strings.Contains(view, "Command: Foo") {
+		t.Errorf("Name screen should preview the derived identifiers, got:\n%s", view)
 	}
 }
 
@@ -97,13 +295,542 @@ func TestModelUpdateDescriptionInput(t *testing.T) {
 		t.Errorf("Expected description to be 'a test', got %q", updatedModel.description)
 	}
 
-	// Test Enter to move to confirm screen
+	// Test Enter to move to the repository screen
 	m = pressKeys(updatedModel, "enter")
 	updatedModel = m.(Model)
 
+	if updatedModel.status != repositoryInput {
+		t.Errorf("After Enter, expected to move to repositoryInput state, got %v", updatedModel.status)
+	}
+}
+
+func TestModelWithConfig(t *testing.T) {
+	cfg := config.Config{
+		AuthorName:    "Jane Doe",
+		AuthorEmail:   "jane@example.com",
+		GitHubUser:    "jane",
+		License:       "Apache-2.0",
+		Flavor:        "vim",
+		OutputDir:     "/src",
+		TestFramework: "busted",
+	}
+	model := NewModelWithConfig(cfg)
+	model.status = descriptionInput
+	model.pluginName = "test-plugin"
+
+	// The repository is prefilled with the GitHub user
+	m := pressKeys(model, "enter")
+	updatedModel := m.(Model)
+	if updatedModel.repository != "jane" {
+		t.Errorf("Expected the repository to be prefilled with jane, got %q", updatedModel.repository)
+	}
+
+	// The flavor of the config file is preselected
+	m = pressKeys(updatedModel, "enter", "enter")
+	updatedModel = m.(Model)
+	if updatedModel.status != flavorSelect || Flavors()[updatedModel.cursor].Name != "vim" {
+		t.Errorf("Expected the vim flavor to be preselected, got status %v and cursor %d", updatedModel.status, updatedModel.cursor)
+	}
+
+	spec := updatedModel.spec()
+	if spec.Author != "Jane Doe <jane@example.com>" || spec.License != "Apache-2.0" || spec.OutputDir != "/src" || spec.TestFramework != "busted" {
+		t.Errorf("Expected the settings in the spec, got %+v", spec)
+	}
+
+	updatedModel.status = confirmScreen
+	view := updatedModel.View()
+	for _, want := range []string{"Author: Jane Doe <jane@example.com>", "License: Apache-2.0", "Directory: " + filepath.Join("/src", "test-plugin")} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Confirm screen should show %q, got:\n%s", want, view)
+		}
+	}
+}
+
+func TestModelProfileSelect(t *testing.T) {
+	cfg := config.Config{
+		AuthorName: "Jane Doe",
+		GitHubUser: "jane",
+		Flavor:     "lua",
+		Profiles: map[string]config.Config{
+			"oss":  {Flavor: "mixed"},
+			"work": {AuthorEmail: "jane@corp.example", License: "Apache-2.0", Flavor: "vim", Host: "git.corp.example"},
+		},
+	}
+
+	// The wizard starts by picking a profile, keeping the settings by default
+	model := NewModelWithConfig(cfg)
+	if model.status != profileSelect {
+		t.Fatalf("Expected to start on the profileSelect state, got %v", model.status)
+	}
+	if view := model.View(); !strings.Contains(view, "(none)") || !strings.Contains(view, "work") {
+		t.Errorf("Profile screen should list the profiles, got:\n%s", view)
+	}
+	m := pressKeys(model, "enter")
+	updatedModel := m.(Model)
+	if updatedModel.status != nameInput || updatedModel.config.Profile != "" || updatedModel.flavor != "lua" {
+		t.Errorf("Expected the settings without a profile, got status %v and %+v", updatedModel.status, updatedModel.config)
+	}
+
+	// Picking a profile applies its settings
+	m = pressKeys(model, "down", "down", "enter")
+	updatedModel = m.(Model)
+	if updatedModel.config.Profile != "work" || updatedModel.flavor != "vim" || updatedModel.config.Author() != "Jane Doe <jane@corp.example>" {
+		t.Errorf("Expected the work profile, got flavor %q and %+v", updatedModel.flavor, updatedModel.config)
+	}
+
+	// Owners live on the host of the profile, the GitHub user is not prefilled
+	updatedModel.pluginName = "test-plugin"
+	updatedModel.status = descriptionInput
+	m = pressKeys(updatedModel, "enter", "team", "enter")
+	updatedModel = m.(Model)
+	if spec := updatedModel.spec(); spec.Host != "git.corp.example" || spec.Owner != "team" || spec.License != "Apache-2.0" {
+		t.Errorf("Expected the repository git.corp.example/team, got %s/%s", spec.Host, spec.Owner)
+	}
+
+	updatedModel.status = confirmScreen
+	view := updatedModel.View()
+	for _, want := range []string{"Profile: work", "Repository: https://git.corp.example/team/test-plugin"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Confirm screen should show %q, got:\n%s", want, view)
+		}
+	}
+
+	// An active profile skips the picker
+	work, _ := cfg.WithProfile("work")
+	if model := NewModelWithConfig(work); model.status != nameInput {
+		t.Errorf("Expected an active profile to skip the picker, got %v", model.status)
+	}
+}
+
+func TestModelUpdateRepositoryInput(t *testing.T) {
+	model := NewModel()
+	model.status = repositoryInput
+	model.pluginName = "test-plugin"
+
+	// An invalid owner keeps the user on the screen
+	m := pressKeys(model, "m", " ", "e", "enter")
+	updatedModel := m.(Model)
+	if updatedModel.status != repositoryInput || updatedModel.inputErr == nil {
+		t.Fatalf("An invalid repository should be rejected, got status %v", updatedModel.status)
+	}
+
+	// A host prefix selects another forge
+	m = pressKeys(updatedModel, "backspace", "backspace", "backspace")
+	m = pressKeys(m, []string{"g", "i", "t", "l", "a", "b", ".", "c", "o", "m", "/", "m", "e", "enter"}...)
+	updatedModel = m.(Model)
+	if updatedModel.status != managersSelect {
+		t.Fatalf("After Enter, expected to move to managersSelect state, got %v", updatedModel.status)
+	}
+
+	// Deselecting every plugin manager is rejected
+	for range PluginManagers() {
+		m = pressKeys(m, " ", "j")
+	}
+	m = pressKeys(m, "enter")
+	updatedModel = m.(Model)
+	if updatedModel.status != managersSelect || updatedModel.inputErr == nil {
+		t.Fatalf("Deselecting every plugin manager should be rejected, got status %v", updatedModel.status)
+	}
+
+	// Select vim-plug and lazy again, in any order
+	m = pressKeys(m, "k", "k", "k", " ", "k", "k", " ", "enter")
+	updatedModel = m.(Model)
+	if updatedModel.status != flavorSelect {
+		t.Fatalf("After Enter, expected to move to flavorSelect state, got %v", updatedModel.status)
+	}
+
+	spec := updatedModel.spec()
+	if spec.Host != "gitlab.com" || spec.Owner != "me" {
+		t.Errorf("Expected repository gitlab.com/me, got %s/%s", spec.Host, spec.Owner)
+	}
+	if len(spec.Installs) != 2 || spec.Installs[0] != "lazy" || spec.Installs[1] != "vim-plug" {
+		t.Errorf("Expected plugin managers [lazy vim-plug], got %q", spec.Installs)
+	}
+}
+
+func TestModelUpdateFlavorSelect(t *testing.T) {
+	// Start with a model in the flavorSelect state
+	model := Model{
+		status:      flavorSelect,
+		pluginName:  "test-theme",
+		description: "A test colorscheme",
+	}
+
+	// Test that the cursor cannot move above the first flavor
+	m := pressKeys(model, "k")
+	updatedModel := m.(Model)
+
+	if updatedModel.cursor != 0 {
+		t.Errorf("Cursor should stay on the first flavor, got %d", updatedModel.cursor)
+	}
+
+	// Test moving down to the colorscheme flavor and selecting it
+	m = pressKeys(updatedModel, "j", "enter")
+	updatedModel = m.(Model)
+
+	if updatedModel.flavor != "colorscheme" {
+		t.Errorf("Expected the colorscheme flavor to be selected, got %q", updatedModel.flavor)
+	}
+	if updatedModel.status != optionsInput {
+		t.Errorf("After Enter, expected to move to optionsInput state, got %v", updatedModel.status)
+	}
+
+	// The options screen is prefilled with the default options of the flavor
+	flavor, _ := LookupFlavor("colorscheme")
+	if len(updatedModel.options) != len(flavor.Options) {
+		t.Errorf("Expected %d prefilled options, got %d", len(flavor.Options), len(updatedModel.options))
+	}
+
+	// Options declared earlier are kept after the defaults of the flavor
+	declared := Option{Name: "enabled", Type: "boolean", Default: "true"}
+	model.options = []Option{declared}
+	m = pressKeys(model, "j", "enter")
+	updatedModel = m.(Model)
+
+	expected := append(append([]Option(nil), flavor.Options...), declared)
+	if !reflect.DeepEqual(updatedModel.options, expected) {
+		t.Errorf("Declared options should be kept, got %+v", updatedModel.options)
+	}
+}
+
+func TestModelChangeFlavor(t *testing.T) {
+	// Start on the confirm screen of a colorscheme with one declared option
+	colorscheme, _ := LookupFlavor("colorscheme")
+	declared := Option{Name: "enabled", Type: "boolean", Default: "true"}
+	model := Model{
+		status:      confirmScreen,
+		pluginName:  "test-plugin",
+		description: "A test plugin",
+		managers:    []string{"lazy"},
+		flavor:      colorscheme.Name,
+		options:     append(append([]Option(nil), colorscheme.Options...), declared),
+	}
+
+	// 'n' goes back to the name, then on to the flavor screen
+	m := pressKeys(model, "n", "enter", "enter", "enter", "enter", "enter")
+	updatedModel := m.(Model)
+	if updatedModel.status != flavorSelect {
+		t.Fatalf("Expected to go back to the flavorSelect state, got %v", updatedModel.status)
+	}
+
+	// Selecting the telescope flavor replaces the colorscheme defaults
+	telescope, _ := LookupFlavor("telescope")
+	for i, flavor := range Flavors() {
+		if flavor.Name == telescope.Name {
+			updatedModel.cursor = i
+		}
+	}
+	m = pressKeys(updatedModel, "enter")
+	updatedModel = m.(Model)
+
+	expected := append(append([]Option(nil), telescope.Options...), declared)
+	if !reflect.DeepEqual(updatedModel.options, expected) {
+		t.Errorf("Expected the telescope options and the declared one, got %+v", updatedModel.options)
+	}
+}
+
+func TestModelUpdateFiletypeInput(t *testing.T) {
+	// Select the filetype flavor, which asks for the language first
+	model := Model{
+		status:      flavorSelect,
+		pluginName:  "my-lang",
+		description: "A test language",
+	}
+	for i, flavor := range Flavors() {
+		if flavor.Name == "filetype" {
+			model.cursor = i
+		}
+	}
+
+	m := pressKeys(model, "enter")
+	updatedModel := m.(Model)
+
+	if updatedModel.status != filetypeInput {
+		t.Fatalf("After selecting the filetype flavor, expected filetypeInput state, got %v", updatedModel.status)
+	}
+	if updatedModel.filetype != "my_lang" {
+		t.Errorf("Expected the filetype to be prefilled with 'my_lang', got %q", updatedModel.filetype)
+	}
+
+	// Test that an invalid filetype is rejected
+	updatedModel.filetype = "My-Lang"
+	m = pressKeys(updatedModel, "enter")
+	updatedModel = m.(Model)
+
+	if updatedModel.inputErr == nil || updatedModel.status != filetypeInput {
+		t.Errorf("Invalid filetype should set an error and stay on filetypeInput, got %v", updatedModel.status)
+	}
+
+	// Test a valid filetype prefilling the extensions
+	updatedModel.filetype = "mylang"
+	m = pressKeys(updatedModel, "enter")
+	updatedModel = m.(Model)
+
+	if updatedModel.status != extensionsInput {
+		t.Fatalf("After a valid filetype, expected extensionsInput state, got %v", updatedModel.status)
+	}
+	if updatedModel.extensions != "mylang" || updatedModel.inputErr != nil {
+		t.Errorf("Expected extensions prefilled with 'mylang' and no error, got %q (%v)", updatedModel.extensions, updatedModel.inputErr)
+	}
+
+	// Test that invalid extensions are rejected
+	updatedModel.extensions = ""
+	m = pressKeys(updatedModel, "enter")
+	updatedModel = m.(Model)
+
+	if updatedModel.inputErr == nil || updatedModel.status != extensionsInput {
+		t.Errorf("Empty extensions should set an error and stay on extensionsInput, got %v", updatedModel.status)
+	}
+
+	// Test valid extensions moving on to the options
+	m = pressKeys(updatedModel, "ml, .mli", "enter")
+	updatedModel = m.(Model)
+
+	if updatedModel.status != optionsInput {
+		t.Errorf("After valid extensions, expected optionsInput state, got %v", updatedModel.status)
+	}
+
+	spec := updatedModel.spec()
+	if spec.Filetype != "mylang" || len(spec.Extensions) != 2 || spec.Extensions[1] != "mli" {
+		t.Errorf("Unexpected language in spec: %q %q", spec.Filetype, spec.Extensions)
+	}
+}
+
+func TestModelUpdateOptionsInput(t *testing.T) {
+	// Start with a model in the optionsInput state
+	model := Model{
+		status:      optionsInput,
+		pluginName:  "test-plugin",
+		description: "A test plugin",
+	}
+
+	// Test declaring a valid option
+	m := pressKeys(model, "width:number:80:Window width", "enter")
+	updatedModel := m.(Model)
+
+	if len(updatedModel.options) != 1 {
+		t.Fatalf("Expected one declared option, got %d", len(updatedModel.options))
+	}
+	expected := Option{Name: "width", Type: "number", Default: "80", Description: "Window width"}
+	if updatedModel.options[0] != expected {
+		t.Errorf("Expected option %+v, got %+v", expected, updatedModel.options[0])
+	}
+	if updatedModel.optionInput != "" {
+		t.Errorf("Option input should be cleared after a valid declaration, got %q", updatedModel.optionInput)
+	}
+
+	// Test that an invalid declaration is rejected and kept for editing
+	m = pressKeys(updatedModel, "width:wide", "enter")
+	updatedModel = m.(Model)
+
+	if updatedModel.inputErr == nil {
+		t.Errorf("Invalid option declaration should set an error")
+	}
+	if len(updatedModel.options) != 1 {
+		t.Errorf("Invalid option declaration should not be added, got %d options", len(updatedModel.options))
+	}
+	if updatedModel.status != optionsInput {
+		t.Errorf("Invalid option declaration should stay on optionsInput state, got %v", updatedModel.status)
+	}
+
+	// Test that Lua keywords and duplicate names are rejected
+	for _, declaration := range []string{"end:boolean:true", "width:string"} {
+		updatedModel.optionInput = ""
+		m = pressKeys(updatedModel, declaration, "enter")
+		updatedModel = m.(Model)
+
+		if updatedModel.inputErr == nil || len(updatedModel.options) != 1 {
+			t.Errorf("Option %q should be rejected, got %d options", declaration, len(updatedModel.options))
+		}
+	}
+
+	// Test that the selected flavor rejects option types it cannot render
+	updatedModel.flavor = "vim"
+	updatedModel.optionInput = ""
+	m = pressKeys(updatedModel, "filetypes:table", "enter")
+	updatedModel = m.(Model)
+
+	if updatedModel.inputErr == nil || len(updatedModel.options) != 1 {
+		t.Errorf("The vim flavor should reject table options, got %d options", len(updatedModel.options))
+	}
+
+	// Test Enter on an empty line skipping the actions screen
+	// for a flavor without actions
+	updatedModel.flavor = "colorscheme"
+	updatedModel.optionInput = ""
+	m = pressKeys(updatedModel, "enter")
+	updatedModel = m.(Model)
+
+	if updatedModel.status != autocmdsSelect {
+		t.Errorf("After Enter on empty line, expected to move to autocmdsSelect state, got %v", updatedModel.status)
+	}
+	if updatedModel.inputErr != nil {
+		t.Errorf("Moving on should clear the option error, got %v", updatedModel.inputErr)
+	}
+}
+
+func TestModelUpdateActionsInput(t *testing.T) {
+	// The default flavor declares actions after the options
+	model := Model{
+		status:     optionsInput,
+		pluginName: "test-plugin",
+	}
+	m := pressKeys(model, "enter")
+	updatedModel := m.(Model)
+
+	if updatedModel.status != actionsInput {
+		t.Fatalf("After Enter on empty line, expected to move to actionsInput state, got %v", updatedModel.status)
+	}
+
+	// Test declaring a valid action
+	m = pressKeys(updatedModel, "toggle:<leader>tt:Toggle the panel", "enter")
+	updatedModel = m.(Model)
+
+	expected := Action{Name: "toggle", Keys: "<leader>tt", Description: "Toggle the panel"}
+	if len(updatedModel.actions) != 1 || updatedModel.actions[0] != expected {
+		t.Fatalf("Expected action %+v, got %+v", expected, updatedModel.actions)
+	}
+	if updatedModel.actionInput != "" {
+		t.Errorf("Action input should be cleared after a valid declaration, got %q", updatedModel.actionInput)
+	}
+
+	// Test that invalid and duplicate declarations are rejected
+	for _, declaration := range []string{"bad name", "toggle", "repeat"} {
+		updatedModel.actionInput = ""
+		m = pressKeys(updatedModel, declaration, "enter")
+		updatedModel = m.(Model)
+
+		if updatedModel.inputErr == nil || len(updatedModel.actions) != 1 {
+			t.Errorf("Action %q should be rejected, got %d actions", declaration, len(updatedModel.actions))
+		}
+	}
+
+	// Test Enter on an empty line to move to the autocommands screen
+	updatedModel.actionInput = ""
+	m = pressKeys(updatedModel, "enter")
+	updatedModel = m.(Model)
+
+	if updatedModel.status != autocmdsSelect {
+		t.Errorf("After Enter on empty line, expected to move to autocmdsSelect state, got %v", updatedModel.status)
+	}
+
+	// Test including the autocommands
+	m = pressKeys(updatedModel, "y")
+	updatedModel = m.(Model)
+
+	if updatedModel.status != rockspecSelect || !updatedModel.autocmds {
+		t.Errorf("After 'y', expected autocommands and the rockspecSelect state, got %v", updatedModel.status)
+	}
+
+	// Test including the rockspec
+	m = pressKeys(updatedModel, "y")
+	updatedModel = m.(Model)
+
+	if updatedModel.status != licenseSelect || !updatedModel.rockspec {
+		t.Errorf("After 'y', expected a rockspec and the licenseSelect state, got %v", updatedModel.status)
+	}
+
+	// Keep the default license, without a git repository
+	m = pressKeys(updatedModel, "enter", "enter")
+	updatedModel = m.(Model)
 	if updatedModel.status != confirmScreen {
 		t.Errorf("After Enter, expected to move to confirmScreen state, got %v", updatedModel.status)
 	}
+	if spec := updatedModel.spec(); len(spec.Actions) != 1 || !spec.Autocmds || !spec.Rockspec {
+		t.Errorf("Expected the action, autocommands and rockspec in the plugin spec, got %+v", spec)
+	}
+	if !strings.Contains(updatedModel.View(), "<Plug>(test-plugin-toggle) on <leader>tt") {
+		t.Errorf("Confirm screen should list the actions")
+	}
+	if !strings.Contains(updatedModel.View(), "Autocommands: yes") {
+		t.Errorf("Confirm screen should show the autocommands choice")
+	}
+	if !strings.Contains(updatedModel.View(), "Rockspec: yes") {
+		t.Errorf("Confirm screen should show the rockspec choice")
+	}
+}
+
+func TestModelVimFlavorSkipsRockspec(t *testing.T) {
+	// Vim script plugins go from the actions to the license screen
+	model := NewModelWithConfig(config.Config{License: "MPL-2.0"})
+	model.status = actionsInput
+	model.pluginName = "test-plugin"
+	model.flavor = "vim"
+	model.rockspec = true
+	m := pressKeys(model, "enter")
+	updatedModel := m.(Model)
+	if updatedModel.status != licenseSelect || updatedModel.licenseChoices()[updatedModel.cursor].ID != "MPL-2.0" {
+		t.Fatalf("Expected the licenseSelect state with MPL-2.0 preselected, got %v", updatedModel.status)
+	}
+
+	// A rockspec chosen for another flavor is left out
+	m = pressKeys(updatedModel, "enter", "enter")
+	updatedModel = m.(Model)
+	if updatedModel.spec().Rockspec {
+		t.Errorf("The vim flavor should not get a rockspec")
+	}
+	if strings.Contains(updatedModel.View(), "Rockspec:") {
+		t.Errorf("Confirm screen should not show the rockspec choice for the vim flavor")
+	}
+}
+
+func TestModelUpdateLicenseSelect(t *testing.T) {
+	// The license of the settings is preselected
+	model := NewModelWithConfig(config.Config{License: "MPL-2.0"})
+	model.status = rockspecSelect
+	m := pressKeys(model, "n")
+	updatedModel := m.(Model)
+	if updatedModel.status != licenseSelect || updatedModel.licenseChoices()[updatedModel.cursor].ID != "MPL-2.0" {
+		t.Fatalf("Expected MPL-2.0 to be preselected, got status %v and cursor %d", updatedModel.status, updatedModel.cursor)
+	}
+	if view := updatedModel.View(); !strings.Contains(view, "Apache-2.0 - Apache License 2.0") {
+		t.Errorf("License screen should list the licenses, got:\n%s", view)
+	}
+
+	m = pressKeys(updatedModel, "up", "enter", "enter")
+	updatedModel = m.(Model)
+	if updatedModel.status != confirmScreen || updatedModel.spec().License != "GPL-3.0-only" {
+		t.Errorf("Expected GPL-3.0-only on the confirmScreen state, got %q and %v", updatedModel.spec().License, updatedModel.status)
+	}
+	if !strings.Contains(updatedModel.View(), "License: GPL-3.0-only") {
+		t.Errorf("Confirm screen should show the license")
+	}
+
+	// Licenses without a built-in text stay available
+	model = NewModelWithConfig(config.Config{License: "LicenseRef-Corp"})
+	model.status = rockspecSelect
+	m = pressKeys(model, "n", "enter", "enter")
+	if got := m.(Model).spec().License; got != "LicenseRef-Corp" {
+		t.Errorf("Expected the license of the settings, got %q", got)
+	}
+}
+
+func TestModelUpdateGitSelect(t *testing.T) {
+	model := NewModel()
+	model.status = gitSelect
+	model.pluginName = "test-plugin"
+	model.repository = "me"
+	if view := model.View(); !strings.Contains(view, "https://github.com/me/test-plugin.git") {
+		t.Errorf("Git screen should show the origin remote, got:\n%s", view)
+	}
+
+	// A missing git is reported on the git screen
+	t.Setenv("PATH", "")
+	m := pressKeys(model, "y")
+	updatedModel := m.(Model)
+	if updatedModel.status != gitSelect || updatedModel.inputErr == nil || updatedModel.gitInit {
+		t.Errorf("Expected an error on the gitSelect state without git, got %v and %v", updatedModel.status, updatedModel.inputErr)
+	}
+
+	// Declining moves on without a repository
+	m = pressKeys(updatedModel, "n")
+	updatedModel = m.(Model)
+	if updatedModel.status != confirmScreen || updatedModel.inputErr != nil || updatedModel.spec().GitInit {
+		t.Errorf("Expected no repository on the confirmScreen state, got %v", updatedModel.status)
+	}
+	if !strings.Contains(updatedModel.View(), "Git Repository: no") {
+		t.Errorf("Confirm screen should show the git choice")
+	}
 }
 
 func TestModelUpdateConfirmScreen(t *testing.T) {
@@ -133,6 +860,35 @@ func TestModelUpdateConfirmScreen(t *testing.T) {
 	// This is simplified for the example
 }
 
+func TestModelUpdateAuthorInput(t *testing.T) {
+	// The author inferred from git is shown on the confirm screen
+	model := NewModelWithConfig(config.Config{AuthorName: "Jane Doe", AuthorEmail: "jane@example.com"})
+	model.status = confirmScreen
+	model.pluginName = "test-plugin"
+	if view := model.View(); !strings.Contains(view, "Author: Jane Doe <jane@example.com>") {
+		t.Errorf("Confirm screen should show the inferred author, got:\n%s", view)
+	}
+
+	// 'e' edits the author, the arrow keys select the name or email
+	m := pressKeys(model, "e")
+	updatedModel := m.(Model)
+	if updatedModel.status != authorInput {
+		t.Fatalf("After 'e', expected the authorInput state, got %v", updatedModel.status)
+	}
+	if view := updatedModel.View(); !strings.Contains(view, "Plugin Author:") || !strings.Contains(view, "jane@example.com") {
+		t.Errorf("Author screen should show the author, got:\n%s", view)
+	}
+
+	m = pressKeys(updatedModel, "backspace", "backspace", "backspace", "Roe", "down", "backspace", "backspace", "backspace", "net", "enter")
+	updatedModel = m.(Model)
+	if updatedModel.status != confirmScreen {
+		t.Errorf("After enter, expected to move back to confirmScreen state, got %v", updatedModel.status)
+	}
+	if got := updatedModel.spec().Author; got != "Jane Roe <jane@example.net>" {
+		t.Errorf("Expected the edited author, got %q", got)
+	}
+}
+
 func TestModelView(t *testing.T) {
 	// Test nameInput view
 	nameModel := Model{
@@ -163,11 +919,55 @@ func TestModelView(t *testing.T) {
 		t.Errorf("descriptionInput view should contain the description")
 	}
 
+	// Test flavorSelect view
+	flavorModel := Model{
+		status: flavorSelect,
+		cursor: 1,
+	}
+
+	flavorView := flavorModel.View()
+	if !strings.Contains(flavorView, "Plugin Flavor:") {
+		t.Errorf("flavorSelect view should contain 'Plugin Flavor:' header")
+	}
+	for _, flavor := range Flavors() {
+		if !strings.Contains(flavorView, flavor.Name+" - "+flavor.Description) {
+			t.Errorf("flavorSelect view should list the %s flavor", flavor.Name)
+		}
+	}
+	if !strings.Contains(flavorView, "> colorscheme") {
+		t.Errorf("flavorSelect view should mark the flavor under the cursor")
+	}
+
+	// Test optionsInput view
+	optionsModel := Model{
+		status:      optionsInput,
+		pluginName:  "test",
+		description: "description",
+		options:     []Option{{Name: "enabled", Type: "boolean", Default: "true", Description: "Enable it"}},
+		optionInput: "width:num",
+		inputErr:    &mockError{message: "invalid type"},
+	}
+
+	optionsView := optionsModel.View()
+	if !strings.Contains(optionsView, "Plugin Options:") {
+		t.Errorf("optionsInput view should contain 'Plugin Options:' header")
+	}
+	if !strings.Contains(optionsView, "enabled (boolean) = true") {
+		t.Errorf("optionsInput view should list the declared options")
+	}
+	if !strings.Contains(optionsView, "width:num") {
+		t.Errorf("optionsInput view should contain the option being typed")
+	}
+	if !strings.Contains(optionsView, "invalid type") {
+		t.Errorf("optionsInput view should contain the option error")
+	}
+
 	// Test confirmScreen view
 	confirmModel := Model{
 		status:      confirmScreen,
 		pluginName:  "test",
 		description: "description",
+		options:     []Option{{Name: "enabled", Type: "boolean", Default: "true", Description: "Enable it"}},
 	}
 
 	confirmView := confirmModel.View()
@@ -180,6 +980,15 @@ func TestModelView(t *testing.T) {
 	if !strings.Contains(confirmView, "Description: description") {
 		t.Errorf("confirmScreen view should contain the description")
 	}
+	if !strings.Contains(confirmView, "Repository: https://github.com/<owner>/test") {
+		t.Errorf("confirmScreen view should contain the repository URL")
+	}
+	if !strings.Contains(confirmView, "Flavor: lua") {
+		t.Errorf("confirmScreen view should contain the default flavor")
+	}
+	if !strings.Contains(confirmView, "enabled (boolean) = true - Enable it") {
+		t.Errorf("confirmScreen view should contain the declared options")
+	}
 
 	// Test done view with success
 	doneSuccessModel := Model{
@@ -219,4 +1028,3 @@ type mockError struct {
 func (e *mockError) Error() string {
 	return e.message
 }
-
@@ -0,0 +1,177 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rivo/uniseg"
+)
+
+// repoSuffixes are stripped from repository names to get the module name,
+// e.g. "foo.nvim" is required as "foo"
+var repoSuffixes = []string{".nvim", ".vim", ".lua", "-nvim", "-vim"}
+
+// moduleName matches Lua module names that map to a single directory under lua/
+// Letters may carry combining marks, as in decomposed accented letters
+var moduleName = regexp.MustCompile(`^\pL[\pL\pM\pN_-]*$`)
+
+// varName matches Lua identifiers, also valid in Vim script variable and autoload names
+var varName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// commandName matches the names Neovim accepts for user commands
+var commandName = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+
+// builtinCommands are the Ex commands starting with an uppercase letter and
+// the commands defined by the runtime files shipped with Neovim, which a user
+// command of the same name would shadow or be shadowed by
+var builtinCommands = []string{
+	// Ex commands
+	"Next", "Print", "X",
+	// Commands of the Neovim runtime and its default plugins
+	"EditQuery", "Inspect", "InspectTree", "Man", "TOhtml", "Tutor", "UpdateRemotePlugins",
+	"Explore", "Hexplore", "Lexplore", "Nexplore", "Pexplore", "Sexplore", "Texplore", "Vexplore",
+	"NetrwClean", "NetrwSettings", "Nread", "Nsource", "Nwrite",
+}
+
+// Names are the identifiers derived from a plugin name, each following the
+// rules of the place it is used in
+type Names struct {
+	Repo    string // Repository and directory name, the plugin name itself, e.g. "my-plugin.nvim"
+	Module  string // Lua module name passed to require(), e.g. "my-plugin"
+	VarName string // Lua and Vim script identifier, e.g. "my_plugin"
+	Command string // User command name, e.g. "MyPlugin"
+}
+
+// ValidateName checks that a plugin name is safe to use as the name of the
+// plugin directory and to derive the other identifiers from
+func ValidateName(name string) error {
+	if name == "" {
+		return fmt.Errorf("the plugin name is required")
+	}
+
+	// Check each user-perceived character, so that a letter followed by
+	// combining marks, as in the decomposed "cafe\u0301", counts as a letter
+	rest, state := name, -1
+	for len(rest) > 0 {
+		var cluster string
+		cluster, rest, _, state = uniseg.FirstGraphemeClusterInString(rest, state)
+		r, size := utf8.DecodeRuneInString(cluster)
+		switch {
+		case r == '/' || r == '\\':
+			return fmt.Errorf("invalid plugin name %q: must not contain path separators", name)
+		case unicode.IsSpace(r):
+			return fmt.Errorf("invalid plugin name %q: must not contain spaces, use hyphens instead", name)
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '_' && r != '.',
+			strings.IndexFunc(cluster[size:], isNotMark) >= 0:
+			return fmt.Errorf("invalid plugin name %q: %q is not allowed, use letters, digits, '-', '_' and '.'", name, cluster)
+		}
+	}
+
+	// Reject names that would point outside of the working directory or
+	// produce empty segments in Lua module names
+	if strings.Contains(name, "..") {
+		return fmt.Errorf("invalid plugin name %q: must not contain '..'", name)
+	}
+	if first, _ := utf8.DecodeRuneInString(name); !unicode.IsLetter(first) {
+		return fmt.Errorf("invalid plugin name %q: must start with a letter", name)
+	}
+	if strings.HasSuffix(name, ".") {
+		return fmt.Errorf("invalid plugin name %q: must not end with '.'", name)
+	}
+	return nil
+}
+
+// isNotMark reports whether a rune is not a combining mark
+func isNotMark(r rune) bool {
+	return !unicode.Is(unicode.M, r)
+}
+
+// NormalizeName derives the identifiers of a valid plugin name
+// The module name drops the suffix of repository names such as "foo.nvim",
+// and replaces the remaining dots as they separate Lua modules in require()
+func NormalizeName(name string) Names {
+	module := name
+	for _, suffix := range repoSuffixes {
+		if trimmed, found := strings.CutSuffix(module, suffix); found && trimmed != "" {
+			module = trimmed
+			break
+		}
+	}
+	return deriveNames(name, strings.ReplaceAll(module, ".", "-"))
+}
+
+// deriveNames derives the identifiers following from the module name
+func deriveNames(repo, module string) Names {
+	return Names{
+		Repo:    repo,
+		Module:  module,
+		VarName: sanitizeVarName(module),
+		Command: pascalCase(module),
+	}
+}
+
+// Validate checks each name against the rules of the place it is used in
+func (n Names) Validate() error {
+	if err := ValidateName(n.Repo); err != nil {
+		return err
+	}
+	if !moduleName.MatchString(n.Module) {
+		return fmt.Errorf("invalid module name %q: must start with a letter and contain only letters, digits, '-' and '_'", n.Module)
+	}
+	if isLuaKeyword(n.Module) {
+		return fmt.Errorf("invalid module name %q: is a Lua keyword", n.Module)
+	}
+	if !varName.MatchString(n.VarName) {
+		return fmt.Errorf("invalid Lua identifier %q: must start with a letter or '_' and contain only letters, digits and '_'", n.VarName)
+	}
+	if isLuaKeyword(n.VarName) {
+		return fmt.Errorf("invalid Lua identifier %q: is a Lua keyword", n.VarName)
+	}
+	return ValidateCommand(n.Command)
+}
+
+// ValidateCommand checks that a user command name is accepted by Neovim and
+// does not collide with a built-in command
+func ValidateCommand(name string) error {
+	if name == "" {
+		return fmt.Errorf("the command name is required")
+	}
+	if !commandName.MatchString(name) {
+		return fmt.Errorf("invalid command name %q: must start with an uppercase letter and contain only ASCII letters and digits", name)
+	}
+	for _, builtin := range builtinCommands {
+		if name == builtin {
+			return fmt.Errorf("invalid command name %q: collides with the built-in :%s command", name, builtin)
+		}
+	}
+	return nil
+}
+
+// Names derives the names of the plugin to generate, applying the overrides
+// of the spec. Overriding the module name also changes the names derived from it
+func (s PluginSpec) Names() (Names, error) {
+	names := NormalizeName(s.Name)
+	if s.ModuleName != "" {
+		names = deriveNames(names.Repo, s.ModuleName)
+	}
+	if s.RepoName != "" {
+		names.Repo = s.RepoName
+	}
+	if s.VarName != "" {
+		names.VarName = s.VarName
+	}
+	if s.CommandName != "" {
+		names.Command = s.CommandName
+	} else if !commandName.MatchString(names.Command) {
+		// Dropping the letters a command cannot contain would be a surprise
+		return Names{}, fmt.Errorf("cannot derive a command name from the module %q: command names only contain ASCII letters and digits, pass one with --command", names.Module)
+	}
+
+	if err := names.Validate(); err != nil {
+		return Names{}, err
+	}
+	return names, nil
+}
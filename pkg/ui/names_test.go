@@ -0,0 +1,98 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateName(t *testing.T) {
+	valid := []string{"my-plugin", "foo.nvim", "nvim_cmp2", "Telescope", "café", "cafe\u0301"}
+	for _, name := range valid {
+		if err := ValidateName(name); err != nil {
+			t.Errorf("ValidateName(%q) failed: %v", name, err)
+		}
+	}
+
+	invalid := []string{"", ".", "..", "../evil", "a/b", `a\b`, "my plugin", "a..b", "-flag", "1plugin", "foo.", "foo:bar", "foo\tbar", "\u0301abc", "a:\u0301"}
+	for _, name := range invalid {
+		if err := ValidateName(name); err == nil {
+			t.Errorf("ValidateName(%q) should have returned an error", name)
+		}
+	}
+}
+
+func TestNormalizeName(t *testing.T) {
+	tests := []struct {
+		name string
+		want Names
+	}{
+		{name: "my-plugin", want: Names{Repo: "my-plugin", Module: "my-plugin", VarName: "my_plugin", Command: "MyPlugin"}},
+		{name: "foo.nvim", want: Names{Repo: "foo.nvim", Module: "foo", VarName: "foo", Command: "Foo"}},
+		{name: "foo-vim", want: Names{Repo: "foo-vim", Module: "foo", VarName: "foo", Command: "Foo"}},
+		{name: "nvim-cmp.lua", want: Names{Repo: "nvim-cmp.lua", Module: "nvim-cmp", VarName: "nvim_cmp", Command: "NvimCmp"}},
+		{name: "vim.nvim", want: Names{Repo: "vim.nvim", Module: "vim", VarName: "vim", Command: "Vim"}},
+		{name: "my.plugin", want: Names{Repo: "my.plugin", Module: "my-plugin", VarName: "my_plugin", Command: "MyPlugin"}},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeName(tt.name); got != tt.want {
+			t.Errorf("NormalizeName(%q) = %+v, want %+v", tt.name, got, tt.want)
+		}
+		if err := NormalizeName(tt.name).Validate(); err != nil {
+			t.Errorf("NormalizeName(%q) derived invalid names: %v", tt.name, err)
+		}
+	}
+}
+
+func TestValidateCommand(t *testing.T) {
+	valid := []string{"Foo", "MyPlugin", "Nvim2", "TOhtmlPlus"}
+	for _, name := range valid {
+		if err := ValidateCommand(name); err != nil {
+			t.Errorf("ValidateCommand(%q) failed: %v", name, err)
+		}
+	}
+
+	invalid := []string{"", "foo", "My-plugin", "My_plugin", "2Fa", "Café", "Man", "Next", "InspectTree"}
+	for _, name := range invalid {
+		if err := ValidateCommand(name); err == nil {
+			t.Errorf("ValidateCommand(%q) should have returned an error", name)
+		}
+	}
+}
+
+func TestPluginSpecNames(t *testing.T) {
+	names, err := PluginSpec{Name: "man.nvim", CommandName: "ManPages"}.Names()
+	if err != nil {
+		t.Fatalf("Names failed: %v", err)
+	}
+	if names.Module != "man" || names.Command != "ManPages" {
+		t.Errorf("Expected the man module and the ManPages command, got %+v", names)
+	}
+
+	// The command derived from man.nvim collides with :Man
+	if _, err := (PluginSpec{Name: "man.nvim"}).Names(); err == nil || !strings.Contains(err.Error(), ":Man") {
+		t.Errorf("Names should reject the Man command, got %v", err)
+	}
+	// Lua keywords cannot be used as identifiers in the generated code
+	for _, spec := range []PluginSpec{{Name: "end"}, {Name: "local.nvim"}, {Name: "foo", VarName: "function"}} {
+		if _, err := spec.Names(); err == nil || !strings.Contains(err.Error(), "Lua keyword") {
+			t.Errorf("Names should reject the Lua keyword in %+v, got %v", spec, err)
+		}
+	}
+	// Commands are not derived from letters they cannot contain
+	for _, name := range []string{"café", "日本"} {
+		if _, err := (PluginSpec{Name: name}).Names(); err == nil || !strings.Contains(err.Error(), "--command") {
+			t.Errorf("Names should ask for a command name for %q, got %v", name, err)
+		}
+	}
+	if names, err := (PluginSpec{Name: "café", CommandName: "Cafe"}).Names(); err != nil || names.VarName != "caf_" {
+		t.Errorf("Names should accept café with a command name, got %+v, %v", names, err)
+	}
+	// Decomposed accented letters are valid in module names
+	if _, err := (PluginSpec{Name: "cafe\u0301", CommandName: "Cafe"}).Names(); err != nil {
+		t.Errorf("Names should accept combining marks in the module, got %v", err)
+	}
+	if _, err := (PluginSpec{Name: "foo", CommandName: "foo"}).Names(); err == nil {
+		t.Errorf("Names should reject a lowercase command")
+	}
+}
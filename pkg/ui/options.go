@@ -0,0 +1,250 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// optionTypes lists the Lua types an option can be declared with
+var optionTypes = []string{"boolean", "number", "string", "table", "function"}
+
+// vimOptionTypes lists the option types that can be rendered as Vim script
+// variables, for the flavors configured with g: variables
+var vimOptionTypes = []string{"boolean", "number", "string"}
+
+// luaIdentifier matches names that can be used as bare keys in a Lua table
+var luaIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// luaNumber matches the numbers Lua 5.1 and LuaJIT both read: decimals with
+// an optional fraction and exponent, and hexadecimal integers, negated or not
+var luaNumber = regexp.MustCompile(`^-?(0[xX][0-9A-Fa-f]+|([0-9]+\.?[0-9]*|\.[0-9]+)([eE][+-]?[0-9]+)?)$`)
+
+// Option describes a single configuration option of the generated plugin.
+// The same schema is rendered into the Lua defaults, the validation code,
+// the type annotations and the README/vimdoc configuration sections, so the
+// defaults never drift between code and docs.
+type Option struct {
+	Name        string // Key in the setup() options table
+	Type        string // Lua type: boolean, number, string, table or function
+	Default     string // Default value as entered by the user
+	Description string // One-line description used in docs and annotations
+}
+
+// ParseOption parses an option declaration of the form
+// name:type[:default[:description]]
+// The description may itself contain colons
+func ParseOption(spec string) (Option, error) {
+	parts := strings.SplitN(spec, ":", 4)
+	if len(parts) < 2 {
+		return Option{}, fmt.Errorf("invalid option %q: expected name:type[:default[:description]]", spec)
+	}
+
+	option := Option{
+		Name: strings.TrimSpace(parts[0]),
+		Type: strings.ToLower(strings.TrimSpace(parts[1])),
+	}
+	if len(parts) > 2 {
+		option.Default = strings.TrimSpace(parts[2])
+	}
+	if len(parts) > 3 {
+		option.Description = strings.TrimSpace(parts[3])
+	}
+	if option.Description == "" {
+		option.Description = "TODO: describe " + option.Name
+	}
+
+	if err := option.Validate(); err != nil {
+		return Option{}, err
+	}
+	return option, nil
+}
+
+// Validate checks that the option can be rendered into valid Lua
+func (o Option) Validate() error {
+	if !luaIdentifier.MatchString(o.Name) {
+		return fmt.Errorf("invalid option name %q: must be a Lua identifier (letters, digits and underscores)", o.Name)
+	}
+	if isLuaKeyword(o.Name) {
+		return fmt.Errorf("invalid option name %q: is a Lua keyword", o.Name)
+	}
+
+	validType := false
+	for _, t := range optionTypes {
+		if o.Type == t {
+			validType = true
+			break
+		}
+	}
+	if !validType {
+		return fmt.Errorf("invalid type %q for option %s: must be one of %s", o.Type, o.Name, strings.Join(optionTypes, ", "))
+	}
+
+	value := strings.TrimSpace(o.Default)
+	if value == "" {
+		return nil
+	}
+	switch o.Type {
+	case "boolean":
+		if v := strings.ToLower(value); v != "true" && v != "false" {
+			return fmt.Errorf("invalid default %q for boolean option %s: must be true or false", o.Default, o.Name)
+		}
+	case "number":
+		// Lua has no literal for infinity or NaN, "inf" would read a nil global
+		if !luaNumber.MatchString(value) {
+			return fmt.Errorf("invalid default %q for number option %s: must be a Lua number literal", o.Default, o.Name)
+		}
+		// Decimals out of range would read as infinity
+		if isDecimal(value) {
+			if _, err := strconv.ParseFloat(value, 64); err != nil {
+				return fmt.Errorf("invalid default %q for number option %s: must be a finite number", o.Default, o.Name)
+			}
+		}
+	case "table":
+		if !strings.HasPrefix(value, "{") || !strings.HasSuffix(value, "}") {
+			return fmt.Errorf("invalid default %q for table option %s: must be a Lua table constructor", o.Default, o.Name)
+		}
+	}
+
+	return nil
+}
+
+// isDecimal reports whether a Lua number literal is written in decimal
+func isDecimal(literal string) bool {
+	return !strings.ContainsAny(literal, "xX")
+}
+
+// mergeOptions adds the options declared by the user to the default options
+// of a flavor, which the flavor templates rely on. A declared option replaces
+// the default option of the same name; others follow the defaults, in order
+func mergeOptions(defaults, declared []Option) []Option {
+	merged := make([]Option, 0, len(defaults)+len(declared))
+	used := make([]bool, len(declared))
+	for _, option := range defaults {
+		for i, d := range declared {
+			if !used[i] && d.Name == option.Name {
+				option, used[i] = d, true
+				break
+			}
+		}
+		merged = append(merged, option)
+	}
+	for i, option := range declared {
+		if !used[i] {
+			merged = append(merged, option)
+		}
+	}
+	return merged
+}
+
+// LuaDefault renders the default value as a Lua expression
+// Strings are quoted, and empty defaults fall back to the zero value of the type
+func (o Option) LuaDefault() string {
+	value := strings.TrimSpace(o.Default)
+
+	switch o.Type {
+	case "string":
+		return luaString(o.Default)
+	case "boolean":
+		if value == "" {
+			return "false"
+		}
+		return strings.ToLower(value)
+	case "number":
+		if value == "" {
+			return "0"
+		}
+		return value
+	case "table":
+		if value == "" {
+			return "{}"
+		}
+		return value
+	case "function":
+		if value == "" {
+			return "function() end"
+		}
+		return value
+	}
+
+	return value
+}
+
+// VimDefault renders the default value as a Vim script expression
+// Only boolean, number and string options have one, see vimOptionTypes
+func (o Option) VimDefault() string {
+	value := strings.TrimSpace(o.Default)
+
+	switch o.Type {
+	case "string":
+		return vimString(o.Default)
+	case "boolean":
+		if strings.ToLower(value) == "true" {
+			return "v:true"
+		}
+		return "v:false"
+	case "number":
+		if value == "" {
+			return "0"
+		}
+		return vimNumber(value)
+	}
+
+	return ""
+}
+
+// vimNumber renders a Lua number literal as a Vim script number, which reads
+// "010" as octal and needs digits on both sides of the point of a float
+func vimNumber(literal string) string {
+	if !isDecimal(literal) {
+		return literal
+	}
+	if !strings.ContainsAny(literal, ".eE") {
+		if n, err := strconv.ParseInt(literal, 10, 64); err == nil {
+			return strconv.FormatInt(n, 10)
+		}
+	}
+
+	n, _ := strconv.ParseFloat(literal, 64)
+	float := strconv.FormatFloat(n, 'g', -1, 64)
+	mantissa, exponent, found := strings.Cut(float, "e")
+	if !strings.Contains(mantissa, ".") {
+		mantissa += ".0"
+	}
+	if found {
+		return mantissa + "e" + exponent
+	}
+	return mantissa
+}
+
+// LuaInvalid renders a Lua value of another type, which validation must reject
+// It is used by the generated tests
+func (o Option) LuaInvalid() string {
+	switch o.Type {
+	case "boolean":
+		return `"yes"`
+	case "number":
+		return `"1"`
+	case "string":
+		return "1"
+	}
+	return "true"
+}
+
+// luaString quotes a Go string as a double-quoted Lua string literal
+func luaString(s string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		`"`, `\"`,
+		"\n", `\n`,
+		"\r", `\r`,
+		"\t", `\t`,
+	)
+	return `"` + replacer.Replace(s) + `"`
+}
+
+// vimString quotes a Go string as a single-quoted Vim script string literal
+func vimString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
@@ -0,0 +1,169 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOptionLuaDefault(t *testing.T) {
+	tests := []struct {
+		option   Option
+		expected string
+	}{
+		{Option{Name: "enabled", Type: "boolean", Default: "true"}, "true"},
+		{Option{Name: "enabled", Type: "boolean", Default: "FALSE"}, "false"},
+		{Option{Name: "enabled", Type: "boolean"}, "false"},
+		{Option{Name: "width", Type: "number", Default: "80"}, "80"},
+		{Option{Name: "width", Type: "number"}, "0"},
+		{Option{Name: "ratio", Type: "number", Default: ".5"}, ".5"},
+		{Option{Name: "delay", Type: "number", Default: "-2.5E-3"}, "-2.5E-3"},
+		{Option{Name: "mask", Type: "number", Default: "0xFF"}, "0xFF"},
+		{Option{Name: "border", Type: "string", Default: "rounded"}, `"rounded"`},
+		{Option{Name: "border", Type: "string"}, `""`},
+		{Option{Name: "quote", Type: "string", Default: `say "hi"\n`}, `"say \"hi\"\\n"`},
+		{Option{Name: "filetypes", Type: "table", Default: `{ "lua" }`}, `{ "lua" }`},
+		{Option{Name: "filetypes", Type: "table"}, "{}"},
+		{Option{Name: "on_attach", Type: "function"}, "function() end"},
+	}
+
+	for _, test := range tests {
+		result := test.option.LuaDefault()
+		if result != test.expected {
+			t.Errorf("%+v.LuaDefault() = %q, expected %q", test.option, result, test.expected)
+		}
+	}
+}
+
+func TestOptionVimDefault(t *testing.T) {
+	tests := []struct {
+		option   Option
+		expected string
+	}{
+		{Option{Name: "enabled", Type: "boolean", Default: "TRUE"}, "v:true"},
+		{Option{Name: "enabled", Type: "boolean"}, "v:false"},
+		{Option{Name: "width", Type: "number", Default: "80"}, "80"},
+		{Option{Name: "width", Type: "number"}, "0"},
+		{Option{Name: "width", Type: "number", Default: "010"}, "10"},
+		{Option{Name: "width", Type: "number", Default: "-0x1F"}, "-0x1F"},
+		{Option{Name: "ratio", Type: "number", Default: ".5"}, "0.5"},
+		{Option{Name: "ratio", Type: "number", Default: "3."}, "3.0"},
+		{Option{Name: "delay", Type: "number", Default: "1e3"}, "1000.0"},
+		{Option{Name: "delay", Type: "number", Default: "-2.5E-3"}, "-0.0025"},
+		{Option{Name: "delay", Type: "number", Default: "1e21"}, "1.0e+21"},
+		{Option{Name: "delay", Type: "number", Default: "99999999999999999999"}, "1.0e+20"},
+		{Option{Name: "border", Type: "string", Default: "rounded"}, "'rounded'"},
+		{Option{Name: "quote", Type: "string", Default: "it's"}, "'it''s'"},
+	}
+
+	for _, test := range tests {
+		result := test.option.VimDefault()
+		if result != test.expected {
+			t.Errorf("%+v.VimDefault() = %q, expected %q", test.option, result, test.expected)
+		}
+	}
+}
+
+func TestOptionLuaInvalid(t *testing.T) {
+	tests := []struct {
+		option   Option
+		expected string
+	}{
+		{Option{Name: "enabled", Type: "boolean"}, `"yes"`},
+		{Option{Name: "width", Type: "number"}, `"1"`},
+		{Option{Name: "border", Type: "string"}, "1"},
+		{Option{Name: "filetypes", Type: "table"}, "true"},
+		{Option{Name: "on_attach", Type: "function"}, "true"},
+	}
+
+	for _, test := range tests {
+		result := test.option.LuaInvalid()
+		if result != test.expected {
+			t.Errorf("%+v.LuaInvalid() = %q, expected %q", test.option, result, test.expected)
+		}
+	}
+}
+
+func TestParseOption(t *testing.T) {
+	tests := []struct {
+		spec     string
+		expected Option
+	}{
+		{
+			"enabled:boolean:true:Enable the plugin",
+			Option{Name: "enabled", Type: "boolean", Default: "true", Description: "Enable the plugin"},
+		},
+		{
+			"width:number:80",
+			Option{Name: "width", Type: "number", Default: "80", Description: "TODO: describe width"},
+		},
+		{
+			"border : String : rounded : Border: see :h nvim_open_win()",
+			Option{Name: "border", Type: "string", Default: "rounded", Description: "Border: see :h nvim_open_win()"},
+		},
+		{
+			"filetypes:table",
+			Option{Name: "filetypes", Type: "table", Description: "TODO: describe filetypes"},
+		},
+	}
+
+	for _, test := range tests {
+		result, err := ParseOption(test.spec)
+		if err != nil {
+			t.Errorf("ParseOption(%q) returned error: %v", test.spec, err)
+			continue
+		}
+		if result != test.expected {
+			t.Errorf("ParseOption(%q) = %+v, expected %+v", test.spec, result, test.expected)
+		}
+	}
+}
+
+func TestParseOptionErrors(t *testing.T) {
+	invalid := []string{
+		"",
+		"enabled",
+		"my-option:boolean",
+		"1st:number",
+		"enabled:bool",
+		"enabled:boolean:yes",
+		"width:number:wide",
+		"width:number:inf",
+		"width:number:-Infinity",
+		"width:number:NaN",
+		"width:number:1e999",
+		"width:number:1_000",
+		"width:number:0x1_0p0",
+		"width:number:0x1p4",
+		"width:number:+1",
+		"width:number:1e",
+		"filetypes:table:lua",
+		"end:boolean:true",
+	}
+
+	for _, spec := range invalid {
+		if _, err := ParseOption(spec); err == nil {
+			t.Errorf("ParseOption(%q) should have returned an error", spec)
+		}
+	}
+}
+
+func TestMergeOptions(t *testing.T) {
+	defaults := []Option{
+		{Name: "server", Type: "string", Default: "lua_ls"},
+		{Name: "filetypes", Type: "table"},
+	}
+	declared := []Option{
+		{Name: "enabled", Type: "boolean", Default: "true"},
+		{Name: "server", Type: "string", Default: "gopls"},
+	}
+	expected := []Option{
+		{Name: "server", Type: "string", Default: "gopls"},
+		{Name: "filetypes", Type: "table"},
+		{Name: "enabled", Type: "boolean", Default: "true"},
+	}
+
+	result := mergeOptions(defaults, declared)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("mergeOptions() = %+v, expected %+v", result, expected)
+	}
+}
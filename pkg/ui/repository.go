@@ -0,0 +1,176 @@
+package ui
+
+import (
+	"bufio"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// defaultHost hosts the plugin repository when no host is given
+const defaultHost = "github.com"
+
+// ownerPlaceholder stands in for the repository owner in the generated
+// installation snippets until it is known
+const ownerPlaceholder = "<owner>"
+
+// ownerPattern matches a user, an organization or a nested group (GitLab)
+var ownerPattern = regexp.MustCompile(`^[A-Za-z0-9_.-]+(/[A-Za-z0-9_.-]+)*$`)
+
+// hostPattern matches a host name with an optional port
+var hostPattern = regexp.MustCompile(`^[A-Za-z0-9]([A-Za-z0-9.-]*[A-Za-z0-9])?(:[0-9]+)?$`)
+
+// ValidateOwner checks that the repository owner can be rendered into a URL
+func ValidateOwner(owner string) error {
+	if !ownerPattern.MatchString(owner) {
+		return fmt.Errorf("invalid repository owner %q: use letters, digits, '-', '_' and '.', with '/' between nested groups", owner)
+	}
+	return nil
+}
+
+// ValidateHost checks that the repository host is a host name, e.g. github.com
+func ValidateHost(host string) error {
+	if !hostPattern.MatchString(host) {
+		return fmt.Errorf("invalid repository host %q: expected a host name such as %s", host, defaultHost)
+	}
+	return nil
+}
+
+// ParseRepository parses the repository of the wizard, given as "owner" or
+// "host/owner" (e.g. "gitlab.com/group"). The host defaults to github.com
+func ParseRepository(repository string) (host, owner string, err error) {
+	return parseRepositoryOn(repository, defaultHost)
+}
+
+// parseRepositoryOn parses a repository like ParseRepository, an owner given
+// without a host living on the fallback host, e.g. the host of a profile
+func parseRepositoryOn(repository, fallbackHost string) (host, owner string, err error) {
+	repository = strings.Trim(strings.TrimSpace(repository), "/")
+	host = fallbackHost
+	owner = repository
+
+	// Owners never contain a dot in their first segment, hosts always do
+	if first, rest, found := strings.Cut(repository, "/"); found && strings.Contains(first, ".") {
+		host, owner = first, rest
+	}
+
+	if err := ValidateHost(host); err != nil {
+		return "", "", err
+	}
+	if err := ValidateOwner(owner); err != nil {
+		return "", "", err
+	}
+	return host, owner, nil
+}
+
+// ParseRemoteURL extracts the host and owner from a git remote URL, either a
+// URL (https://github.com/owner/repo.git) or an scp-like address
+// (git@github.com:owner/repo.git)
+func ParseRemoteURL(remote string) (host, owner string, ok bool) {
+	var path string
+	if strings.Contains(remote, "://") {
+		u, err := url.Parse(remote)
+		if err != nil {
+			return "", "", false
+		}
+		// Only web URLs keep their port, SSH ports are not part of the clone URL
+		host = u.Hostname()
+		if u.Scheme == "http" || u.Scheme == "https" {
+			host = u.Host
+		}
+		path = u.Path
+	} else {
+		address, rest, found := strings.Cut(remote, ":")
+		if !found {
+			return "", "", false
+		}
+		host = address[strings.LastIndex(address, "@")+1:]
+		path = rest
+	}
+
+	// The last segment is the repository, the ones before it the owner
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	slash := strings.LastIndex(path, "/")
+	if slash < 0 {
+		return "", "", false
+	}
+	owner = path[:slash]
+
+	if ValidateHost(host) != nil || ValidateOwner(owner) != nil {
+		return "", "", false
+	}
+	return host, owner, true
+}
+
+// readGitRemote reads the host and owner of the repository at dir from the
+// URL of its origin remote, falling back to the first remote declared in
+// .git/config. It reports false when dir is not a clone with a remote
+func readGitRemote(dir string) (host, owner string, ok bool) {
+	file, err := os.Open(filepath.Join(dir, ".git", "config"))
+	if err != nil {
+		return "", "", false
+	}
+	defer file.Close()
+
+	var remote, firstURL, originURL string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		// Section headers look like [remote "origin"]
+		if strings.HasPrefix(line, "[") {
+			remote = ""
+			if name, found := strings.CutPrefix(line, "[remote "); found {
+				remote = strings.Trim(strings.TrimSuffix(name, "]"), `"`)
+			}
+			continue
+		}
+
+		key, value, found := strings.Cut(line, "=")
+		if remote == "" || !found || strings.TrimSpace(key) != "url" {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if firstURL == "" {
+			firstURL = value
+		}
+		if remote == "origin" {
+			originURL = value
+		}
+	}
+
+	if originURL == "" {
+		originURL = firstURL
+	}
+	return ParseRemoteURL(originURL)
+}
+
+// RepoSlug returns the owner/repository reference of the plugin
+func (d TemplateData) RepoSlug() string {
+	owner := d.Owner
+	if owner == "" {
+		owner = ownerPlaceholder
+	}
+	return owner + "/" + d.RepoName
+}
+
+// RepoURL returns the URL the plugin repository is cloned from
+func (d TemplateData) RepoURL() string {
+	host := d.Host
+	if host == "" {
+		host = defaultHost
+	}
+	return "https://" + host + "/" + d.RepoSlug()
+}
+
+// PluginSource returns the plugin reference used by plugin managers: the
+// owner/repository shorthand on GitHub, the full URL on other hosts
+func (d TemplateData) PluginSource() string {
+	if d.Host == "" || d.Host == defaultHost {
+		return d.RepoSlug()
+	}
+	return d.RepoURL()
+}
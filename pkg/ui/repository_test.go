@@ -0,0 +1,116 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseRepository(t *testing.T) {
+	tests := []struct {
+		input     string
+		wantHost  string
+		wantOwner string
+		wantErr   bool
+	}{
+		{input: "me", wantHost: "github.com", wantOwner: "me"},
+		{input: " my-org/ ", wantHost: "github.com", wantOwner: "my-org"},
+		{input: "gitlab.com/group", wantHost: "gitlab.com", wantOwner: "group"},
+		{input: "git.example.com:8443/group/sub", wantHost: "git.example.com:8443", wantOwner: "group/sub"},
+		{input: "", wantErr: true},
+		{input: "m e", wantErr: true},
+		{input: "gitlab.com/my group", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			host, owner, err := ParseRepository(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseRepository(%q) should have returned an error", tt.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseRepository(%q) failed: %v", tt.input, err)
+			}
+			if host != tt.wantHost || owner != tt.wantOwner {
+				t.Errorf("ParseRepository(%q) = %s, %s, want %s, %s", tt.input, host, owner, tt.wantHost, tt.wantOwner)
+			}
+		})
+	}
+}
+
+func TestParseRemoteURL(t *testing.T) {
+	tests := []struct {
+		remote    string
+		wantHost  string
+		wantOwner string
+		wantOK    bool
+	}{
+		{remote: "https://github.com/me/demo.nvim.git", wantHost: "github.com", wantOwner: "me", wantOK: true},
+		{remote: "https://git.example.com:8443/group/sub/demo", wantHost: "git.example.com:8443", wantOwner: "group/sub", wantOK: true},
+		{remote: "git@github.com:me/demo.git", wantHost: "github.com", wantOwner: "me", wantOK: true},
+		{remote: "ssh://git@gitlab.com:22/group/demo.git", wantHost: "gitlab.com", wantOwner: "group", wantOK: true},
+		{remote: "/srv/git/demo.git", wantOK: false},
+		{remote: "https://github.com/demo", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.remote, func(t *testing.T) {
+			host, owner, ok := ParseRemoteURL(tt.remote)
+			if ok != tt.wantOK || host != tt.wantHost || owner != tt.wantOwner {
+				t.Errorf("ParseRemoteURL(%q) = %q, %q, %v, want %q, %q, %v", tt.remote, host, owner, ok, tt.wantHost, tt.wantOwner, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestReadGitRemote(t *testing.T) {
+	dir := t.TempDir()
+	if _, _, ok := readGitRemote(dir); ok {
+		t.Errorf("A directory without .git should have no remote")
+	}
+
+	config := `[core]
+	bare = false
+[remote "upstream"]
+	url = https://github.com/upstream/demo.git
+[remote "origin"]
+	url = git@codeberg.org:me/demo.git
+	fetch = +refs/heads/*:refs/remotes/origin/*
+[branch "main"]
+	remote = origin
+`
+	if err := os.MkdirAll(filepath.Join(dir, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".git", "config"), []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	host, owner, ok := readGitRemote(dir)
+	if !ok || host != "codeberg.org" || owner != "me" {
+		t.Errorf("readGitRemote() = %q, %q, %v, want the origin remote codeberg.org, me", host, owner, ok)
+	}
+}
+
+func TestTemplateDataRepository(t *testing.T) {
+	data := TemplateData{RepoName: "demo"}
+	if got, want := data.PluginSource(), "<owner>/demo"; got != want {
+		t.Errorf("PluginSource() without owner = %q, want %q", got, want)
+	}
+
+	data = TemplateData{RepoName: "demo", Host: "github.com", Owner: "me"}
+	if got, want := data.PluginSource(), "me/demo"; got != want {
+		t.Errorf("PluginSource() on GitHub = %q, want %q", got, want)
+	}
+	if got, want := data.RepoURL(), "https://github.com/me/demo"; got != want {
+		t.Errorf("RepoURL() = %q, want %q", got, want)
+	}
+
+	data = TemplateData{RepoName: "demo", Host: "gitlab.com", Owner: "group/sub"}
+	if got, want := data.PluginSource(), "https://gitlab.com/group/sub/demo"; got != want {
+		t.Errorf("PluginSource() on another host = %q, want %q", got, want)
+	}
+}
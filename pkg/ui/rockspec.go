@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"path"
+	"strconv"
+	"strings"
+)
+
+// rockspecFiles package the plugin for luarocks, generated on request
+var rockspecFiles = []templateFile{
+	{outputPath: "{{.RepoName}}-scm-1.rockspec", tmplPath: "templates/plugin-scm-1.rockspec.tmpl"},
+	// go:embed skips directories starting with a dot, hence no ".github" template dir
+	{outputPath: ".github/workflows/release.yml", tmplPath: "templates/github/workflows/release.yml.tmpl"},
+}
+
+// bustedFile runs the tests with busted and nlua, for `luarocks test`
+var bustedFile = templateFile{outputPath: ".busted", tmplPath: "templates/busted.tmpl"}
+
+// LuaModule maps a Lua module to the file defining it, as in the modules
+// table of a rockspec
+type LuaModule struct {
+	Name string // Module name passed to require(), e.g. "my-plugin.config"
+	Path string // File relative to the plugin directory, e.g. "lua/my-plugin/config.lua"
+}
+
+// luaModules derives the Lua modules from the generated files under lua/
+func luaModules(paths []string) []LuaModule {
+	var modules []LuaModule
+	for _, p := range paths {
+		name, found := strings.CutPrefix(p, "lua/")
+		if !found || path.Ext(name) != ".lua" {
+			continue
+		}
+		name = strings.TrimSuffix(name, ".lua")
+		name = strings.TrimSuffix(name, "/init")
+		modules = append(modules, LuaModule{Name: strings.ReplaceAll(name, "/", "."), Path: p})
+	}
+	return modules
+}
+
+// runtimeDirs lists the top-level directories of the generated files that
+// Neovim loads from the runtimepath, in order of appearance. lua/ is left
+// out as its files are installed as modules, and so are the tests and CI
+func runtimeDirs(paths []string) []string {
+	var dirs []string
+	seen := map[string]bool{"lua": true, "tests": true, ".github": true}
+	for _, p := range paths {
+		dir, _, found := strings.Cut(p, "/")
+		if !found || seen[dir] {
+			continue
+		}
+		seen[dir] = true
+		dirs = append(dirs, dir)
+	}
+	return dirs
+}
+
+// hasTests reports whether the generated files include the test scaffold
+func hasTests(paths []string) bool {
+	for _, p := range paths {
+		if strings.HasPrefix(p, "tests/") {
+			return true
+		}
+	}
+	return false
+}
+
+// LuaSummary renders the description as a Lua string literal
+func (d TemplateData) LuaSummary() string {
+	return luaString(d.Description)
+}
+
+// YAMLSummary renders the description as a double-quoted YAML string
+func (d TemplateData) YAMLSummary() string {
+	return strconv.Quote(d.Description)
+}
+
+// LuaAuthor renders the author as a Lua string literal
+func (d TemplateData) LuaAuthor() string {
+	return luaString(d.Author)
+}
+
+// LuaRuntimeDirs renders the runtime directories as a Lua list
+func (d TemplateData) LuaRuntimeDirs() string {
+	return luaList(d.RuntimeDirs)
+}
@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLuaModules(t *testing.T) {
+	paths := []string{
+		"README.md",
+		"plugin/demo.lua",
+		"lua/demo/init.lua",
+		"lua/demo/config.lua",
+		"lua/telescope/_extensions/demo.lua",
+		"queries/demo/highlights.scm",
+	}
+
+	want := []LuaModule{
+		{Name: "demo", Path: "lua/demo/init.lua"},
+		{Name: "demo.config", Path: "lua/demo/config.lua"},
+		{Name: "telescope._extensions.demo", Path: "lua/telescope/_extensions/demo.lua"},
+	}
+	if got := luaModules(paths); !reflect.DeepEqual(got, want) {
+		t.Errorf("luaModules() = %+v, want %+v", got, want)
+	}
+}
+
+func TestRuntimeDirs(t *testing.T) {
+	paths := []string{
+		"README.md",
+		"plugin/demo.lua",
+		"lua/demo/init.lua",
+		"ftdetect/demo.lua",
+		"after/syntax/demo.vim",
+		"tests/demo_spec.lua",
+		".github/workflows/release.yml",
+		"plugin/other.lua",
+	}
+
+	want := []string{"plugin", "ftdetect", "after"}
+	if got := runtimeDirs(paths); !reflect.DeepEqual(got, want) {
+		t.Errorf("runtimeDirs() = %q, want %q", got, want)
+	}
+	if !hasTests(paths) || hasTests(paths[:3]) {
+		t.Errorf("hasTests() should report the files under tests/")
+	}
+}
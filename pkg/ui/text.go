@@ -0,0 +1,34 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/rivo/uniseg"
+)
+
+// trimLastGrapheme deletes the last user-perceived character of a string,
+// keeping multi-byte characters, combining marks and emoji sequences whole
+func trimLastGrapheme(s string) string {
+	rest, state := s, -1
+	last, offset := 0, 0
+	for len(rest) > 0 {
+		var cluster string
+		cluster, rest, _, state = uniseg.FirstGraphemeClusterInString(rest, state)
+		last = offset
+		offset += len(cluster)
+	}
+	return s[:last]
+}
+
+// capitalizeFirst capitalizes the first character of a string
+// Used by pascalCase for each word of a name
+func capitalizeFirst(s string) string {
+	first, rest, _, _ := uniseg.FirstGraphemeClusterInString(s, -1)
+	return strings.ToUpper(first) + rest
+}
+
+// underline returns a line of = as wide as the text in a terminal, so that
+// headers line up under names with wide or combining characters
+func underline(text string) string {
+	return strings.Repeat("=", uniseg.StringWidth(text))
+}
@@ -0,0 +1,44 @@
+package ui
+
+import "testing"
+
+func TestTrimLastGrapheme(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"abc", "ab"},
+		{"café", "caf"},
+		{"cafe\u0301", "caf"}, // e followed by a combining acute accent
+		{"日本", "日"},
+		{"hi 👍🏽", "hi "},
+		{"🇫🇷", ""},
+		{"a", ""},
+		{"", ""},
+	}
+
+	for _, test := range tests {
+		if result := trimLastGrapheme(test.input); result != test.expected {
+			t.Errorf("trimLastGrapheme(%q) = %q, expected %q", test.input, result, test.expected)
+		}
+	}
+}
+
+func TestUnderline(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"MY-PLUGIN", "========="},
+		{"CAFÉ", "===="},
+		{"CAFE\u0301", "===="},
+		{"日本", "===="},
+		{"", ""},
+	}
+
+	for _, test := range tests {
+		if result := underline(test.input); result != test.expected {
+			t.Errorf("underline(%q) = %q, expected %q", test.input, result, test.expected)
+		}
+	}
+}